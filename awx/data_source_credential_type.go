@@ -0,0 +1,163 @@
+/*
+Looks up a credential type, like the types AWX ships by their namespace, to
+create credentials of it with awx_credential.
+
+# Example Usage
+
+```hcl
+
+	data "awx_credential_type" "aws" {
+	  namespace = "aws"
+	}
+
+	resource "awx_credential" "aws" {
+	  name               = "AWS"
+	  organisation_id    = awx_organization.default.id
+	  credential_type_id = data.awx_credential_type.aws.id
+
+	  inputs = {
+	    username = var.aws_access_key
+	    password = var.aws_secret_key
+	  }
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// credentialTypeDataSelectors are the arguments a credential type is looked up by.
+var credentialTypeDataSelectors = []string{"id", "name", "namespace"}
+
+func dataSourceCredentialType() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCredentialTypeRead,
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: credentialTypeDataSelectors,
+				Description:  "Numeric ID of the credential type",
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: credentialTypeDataSelectors,
+				Description:  "Name of the credential type",
+			},
+			"namespace": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: credentialTypeDataSelectors,
+				Description:  "Namespace of a credential type AWX ships, like aws, scm or hashivault_kv",
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the credential type",
+			},
+			"kind": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Kind of the credential type, like ssh, scm, cloud, net, vault or external",
+			},
+			"managed": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether AWX ships the credential type",
+			},
+			"input": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Input fields of the credentials",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"label": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"secret": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"multiline": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"help_text": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"format": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"choices": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"required_inputs": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Ids of the inputs every credential has to set",
+			},
+		},
+	}
+}
+
+func dataSourceCredentialTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	params := map[string]string{}
+	if id, ok := d.GetOk("id"); ok {
+		params["id"] = strconv.Itoa(id.(int))
+	}
+	if name, ok := d.GetOk("name"); ok {
+		params["name"] = name.(string)
+	}
+	if namespace, ok := d.GetOk("namespace"); ok {
+		params["namespace"] = namespace.(string)
+		params["managed"] = "true"
+	}
+
+	t, err := findCredentialType(m, params)
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"Get: Fail to fetch CredentialType",
+			"Fail to find the credential type got: %s",
+			err.Error(),
+		)
+	}
+
+	d.Set("name", t.Name)
+	d.Set("description", t.Description)
+	d.Set("namespace", t.Namespace)
+	d.Set("kind", t.Kind)
+	d.Set("managed", t.Managed)
+	d.Set("input", flattenCredentialTypeFields(t))
+	d.Set("required_inputs", t.Inputs.Required)
+	d.SetId(strconv.Itoa(t.ID))
+	return diags
+}
@@ -65,7 +65,6 @@ func dataSourceInventoriesRead(ctx context.Context, d *schema.ResourceData, m in
 			"Get: Missing Parameters",
 			"Please use one of the selectors (name or group_id)",
 		)
-		return diags
 	}
 	inventories, _, err := client.InventoriesService.ListInventories(params)
 	if err != nil {
@@ -81,7 +80,6 @@ func dataSourceInventoriesRead(ctx context.Context, d *schema.ResourceData, m in
 			"The Query Returns more than one Group, %d",
 			len(inventories),
 		)
-		return diags
 	}
 
 	inventory := inventories[0]
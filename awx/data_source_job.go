@@ -0,0 +1,258 @@
+/*
+Reads a job by id, or the latest job of a job template, with its stdout and
+the artifacts reported by set_stats.
+
+# Example Usage
+
+```hcl
+
+	data "awx_job" "cluster" {
+	  job_template_name = "create-cluster"
+	}
+
+	output "cluster_token" {
+	  value     = data.awx_job.cluster.artifacts["cluster_token"]
+	  sensitive = true
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+const jobsAPIEndpoint = "/api/v2/jobs/"
+
+// ansiEscapePattern matches the ANSI escape sequences Ansible colors its output with.
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// jobSelectors are the arguments a job is looked up by.
+var jobSelectors = []string{"id", "job_template_id", "job_template_name"}
+
+type jobDetail struct {
+	unifiedJob
+	Name        string                 `json:"name"`
+	JobTemplate int                    `json:"job_template"`
+	ExtraVars   string                 `json:"extra_vars"`
+	Artifacts   map[string]interface{} `json:"artifacts"`
+}
+
+func dataSourceJob() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceJobRead,
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: jobSelectors,
+				Description:  "Numeric ID of the job",
+			},
+			"job_template_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: jobSelectors,
+				Description:  "Numeric ID of the job template, the latest job of the template is read",
+			},
+			"job_template_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: jobSelectors,
+				Description:  "Name of the job template, the latest job of the template is read",
+			},
+			"stdout_max_bytes": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      65536,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum size of stdout, longer output keeps its end. 0 skips reading stdout",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the job",
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the job",
+			},
+			"failed": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the job failed",
+			},
+			"started": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Start time of the job, RFC 3339",
+			},
+			"finished": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Finish time of the job, RFC 3339",
+			},
+			"elapsed": &schema.Schema{
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Run time of the job in seconds",
+			},
+			"extra_vars": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Extra variables the job was launched with, as JSON. Sensitive, they can hold secrets",
+			},
+			"artifacts": &schema.Schema{
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Artifacts reported by set_stats, values that aren't strings are JSON encoded. Sensitive, they can hold secrets",
+			},
+			"artifacts_json": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Artifacts reported by set_stats as JSON. Sensitive, they can hold secrets",
+			},
+			"stdout": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Output of the job without ANSI escape sequences",
+			},
+			"stdout_truncated": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether stdout was cut to stdout_max_bytes",
+			},
+		},
+	}
+}
+
+func dataSourceJobRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := findJobID(d, m, "id")
+	if diags.HasError() {
+		return diags
+	}
+
+	job := new(jobDetail)
+	if err := apiGet(m, fmt.Sprintf("%s%d/", jobsAPIEndpoint, id), job, nil); err != nil {
+		return buildDiagNotFoundFail("job", id, err)
+	}
+
+	stdout := ""
+	truncated := false
+	if maxBytes := d.Get("stdout_max_bytes").(int); maxBytes > 0 {
+		// The stripped escape codes can take as much room as the text, the
+		// tail read holds twice the limit.
+		raw, cut, err := apiGetTextTail(m, fmt.Sprintf("%s%d/stdout/", jobsAPIEndpoint, id), map[string]string{"format": "txt_download"}, 2*maxBytes)
+		if err != nil {
+			return buildDiagNotFoundFail("job stdout", id, err)
+		}
+		if cut {
+			// The first line can start inside an escape code.
+			if i := strings.IndexByte(raw, '\n'); i >= 0 {
+				raw = raw[i+1:]
+			}
+		}
+		stdout, truncated = truncateStdout(ansiEscapePattern.ReplaceAllString(raw, ""), maxBytes)
+		truncated = truncated || cut
+	}
+
+	artifacts := map[string]string{}
+	for key, value := range job.Artifacts {
+		if s, ok := value.(string); ok {
+			artifacts[key] = s
+			continue
+		}
+		b, _ := json.Marshal(value)
+		artifacts[key] = string(b)
+	}
+	artifactsJSON := "{}"
+	if len(job.Artifacts) > 0 {
+		b, _ := json.Marshal(job.Artifacts)
+		artifactsJSON = string(b)
+	}
+
+	d.Set("job_template_id", job.JobTemplate)
+	d.Set("name", job.Name)
+	d.Set("status", job.Status)
+	d.Set("failed", job.Failed)
+	d.Set("started", formatJobTime(job.Started))
+	d.Set("finished", formatJobTime(job.Finished))
+	d.Set("elapsed", job.Elapsed)
+	d.Set("extra_vars", job.ExtraVars)
+	d.Set("artifacts", artifacts)
+	d.Set("artifacts_json", artifactsJSON)
+	d.Set("stdout", stdout)
+	d.Set("stdout_truncated", truncated)
+	d.SetId(strconv.Itoa(job.ID))
+	return diags
+}
+
+// findJobID returns the job id given as idKey, or the id of the latest job of
+// the job template given as job_template_id or job_template_name.
+func findJobID(d *schema.ResourceData, m interface{}, idKey string) (int, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if id, ok := d.GetOk(idKey); ok {
+		return id.(int), diags
+	}
+
+	jobTemplateID := d.Get("job_template_id").(int)
+	if name, ok := d.GetOk("job_template_name"); ok {
+		jobTemplate, diags := findJobTemplate(m.(*awx.AWX), map[string]string{"name": name.(string)})
+		if diags.HasError() {
+			return 0, diags
+		}
+		jobTemplateID = jobTemplate.ID
+	}
+
+	page := new(apiPage)
+	err := apiGet(m, jobsAPIEndpoint, page, map[string]string{
+		"job_template": strconv.Itoa(jobTemplateID),
+		"order_by":     "-id",
+		"page_size":    "1",
+	})
+	if err != nil {
+		return 0, buildDiagNotFoundFail("jobs of job template", jobTemplateID, err)
+	}
+	var jobs []unifiedJob
+	if err := json.Unmarshal(page.Results, &jobs); err != nil {
+		return 0, buildDiagNotFoundFail("jobs of job template", jobTemplateID, err)
+	}
+	if len(jobs) == 0 {
+		return 0, buildDiagnosticsMessage(
+			"Get: Job not found",
+			"JobTemplate with id %d has no job",
+			jobTemplateID,
+		)
+	}
+	return jobs[0].ID, diags
+}
+
+// truncateStdout keeps the last maxBytes of stdout, the end holds the play recap.
+func truncateStdout(stdout string, maxBytes int) (string, bool) {
+	if len(stdout) <= maxBytes {
+		return stdout, false
+	}
+	start := len(stdout) - maxBytes
+	for start < len(stdout) && !utf8.RuneStart(stdout[start]) {
+		start++
+	}
+	return stdout[start:], true
+}
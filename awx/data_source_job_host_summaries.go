@@ -0,0 +1,180 @@
+/*
+Reads the per host results of a job, or of the latest job of a job template,
+to gate downstream resources on a clean run.
+
+# Example Usage
+
+```hcl
+
+	data "awx_job_host_summaries" "site" {
+	  job_template_id = awx_job_template.site.id
+	}
+
+	resource "awx_workflow_job_launch" "promote" {
+	  workflow_job_template_id = awx_workflow_job_template.promote.id
+
+	  lifecycle {
+	    precondition {
+	      condition     = length(data.awx_job_host_summaries.site.failed_hosts) == 0
+	      error_message = "The last site run failed on some hosts."
+	    }
+	  }
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+// jobHostSummariesSelectors are the arguments the job is looked up by.
+var jobHostSummariesSelectors = []string{"job_id", "job_template_id", "job_template_name"}
+
+func dataSourceJobHostSummaries() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceJobHostSummariesRead,
+		Schema: map[string]*schema.Schema{
+			"job_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: jobHostSummariesSelectors,
+				Description:  "Numeric ID of the job",
+			},
+			"job_template_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: jobHostSummariesSelectors,
+				Description:  "Numeric ID of the job template, the latest job of the template is read",
+			},
+			"job_template_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: jobHostSummariesSelectors,
+				Description:  "Name of the job template, the latest job of the template is read",
+			},
+			"hosts": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Task counts of every host of the job",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host_id": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Numeric ID of the host",
+						},
+						"host_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the host",
+						},
+						"ok": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of ok tasks",
+						},
+						"changed": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of changed tasks",
+						},
+						"failures": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of failed tasks",
+						},
+						"dark": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of tasks the host was unreachable for",
+						},
+						"skipped": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of skipped tasks",
+						},
+						"failed": &schema.Schema{
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the job failed on the host",
+						},
+					},
+				},
+			},
+			"failed_hosts": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the hosts with failed tasks",
+			},
+			"unreachable_hosts": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the hosts that were unreachable",
+			},
+		},
+	}
+}
+
+func dataSourceJobHostSummariesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*awx.AWX)
+	id, diags := findJobID(d, m, "job_id")
+	if diags.HasError() {
+		return diags
+	}
+
+	var summaries []awx.HostSummary
+	params := map[string]string{"order_by": "host_name", "page_size": "200"}
+	for page := 1; ; page++ {
+		params["page"] = strconv.Itoa(page)
+		results, res, err := client.JobService.GetHostSummaries(id, params)
+		if err != nil {
+			return buildDiagNotFoundFail("job host summaries", id, err)
+		}
+		summaries = append(summaries, results...)
+		if res.Next == nil {
+			break
+		}
+	}
+
+	hosts := make([]interface{}, 0, len(summaries))
+	failedHosts := []string{}
+	unreachableHosts := []string{}
+	for _, summary := range summaries {
+		hosts = append(hosts, map[string]interface{}{
+			"host_id":   summary.Host,
+			"host_name": summary.HostName,
+			"ok":        summary.Ok,
+			"changed":   summary.Changed,
+			"failures":  summary.Failures,
+			"dark":      summary.Dark,
+			"skipped":   summary.Skipped,
+			"failed":    summary.Failed,
+		})
+		if summary.Failures > 0 || summary.Failed {
+			failedHosts = append(failedHosts, summary.HostName)
+		}
+		if summary.Dark > 0 {
+			unreachableHosts = append(unreachableHosts, summary.HostName)
+		}
+	}
+	sort.Strings(failedHosts)
+	sort.Strings(unreachableHosts)
+
+	d.Set("job_id", id)
+	d.Set("hosts", hosts)
+	d.Set("failed_hosts", failedHosts)
+	d.Set("unreachable_hosts", unreachableHosts)
+	d.SetId(strconv.Itoa(id))
+	return diags
+}
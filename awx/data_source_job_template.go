@@ -42,7 +42,6 @@ func dataSourceJobTemplate() *schema.Resource {
 }
 
 func dataSourceJobTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
 	params := make(map[string]string)
 	if groupName, okName := d.GetOk("name"); okName {
@@ -60,10 +59,21 @@ func dataSourceJobTemplateRead(ctx context.Context, d *schema.ResourceData, m in
 		)
 	}
 
+	jobTemplate, diags := findJobTemplate(client, params)
+	if diags.HasError() {
+		return diags
+	}
+	d = setJobTemplateResourceData(d, jobTemplate)
+	return diags
+}
+
+// findJobTemplate looks up the job template matching the name or id of params.
+func findJobTemplate(client *awx.AWX, params map[string]string) (*awx.JobTemplate, diag.Diagnostics) {
+	var diags diag.Diagnostics
 	jobTemplate, _, err := client.JobTemplateService.ListJobTemplates(params)
 
 	if err != nil {
-		return buildDiagnosticsMessage(
+		return nil, buildDiagnosticsMessage(
 			"Get: Fail to fetch Inventory Group",
 			"Fail to find the group got: %s",
 			err.Error(),
@@ -73,24 +83,22 @@ func dataSourceJobTemplateRead(ctx context.Context, d *schema.ResourceData, m in
 	for _, template := range jobTemplate {
 		log.Printf("loop %v", template.Name)
 		if template.Name == params["name"] {
-			d = setJobTemplateResourceData(d, template)
-			return diags
+			return template, diags
 		}
 	}
 
-	if _, okGroupID := d.GetOk("id"); okGroupID {
+	if _, okGroupID := params["id"]; okGroupID {
 		log.Printf("byid %v", len(jobTemplate))
 		if len(jobTemplate) != 1 {
-			return buildDiagnosticsMessage(
+			return nil, buildDiagnosticsMessage(
 				"Get: find more than one Element",
 				"The Query Returns more than one Group, %d",
 				len(jobTemplate),
 			)
 		}
-		d = setJobTemplateResourceData(d, jobTemplate[0])
-		return diags
+		return jobTemplate[0], diags
 	}
-	return buildDiagnosticsMessage(
+	return nil, buildDiagnosticsMessage(
 		"Get: find more than one Element",
 		"The Query Returns more than one Group, %d",
 		len(jobTemplate),
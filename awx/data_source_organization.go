@@ -56,7 +56,6 @@ func dataSourceOrganizationsRead(ctx context.Context, d *schema.ResourceData, m
 			"Get: Missing Parameters",
 			"Please use one of the selectors (name or group_id)",
 		)
-		return diags
 	}
 	organizations, _, err := client.OrganizationsService.ListOrganizations(params)
 	if err != nil {
@@ -72,7 +71,6 @@ func dataSourceOrganizationsRead(ctx context.Context, d *schema.ResourceData, m
 			"The Query Returns more than one Group, %d",
 			len(organizations),
 		)
-		return diags
 	}
 
 	organization := organizations[0]
@@ -0,0 +1,118 @@
+/*
+Looks up one of the system job templates AWX ships, like the cleanup of job
+details or of the activity stream.
+
+# Example Usage
+
+```hcl
+
+	data "awx_system_job_template" "cleanup_jobs" {
+	  job_type = "cleanup_jobs"
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const systemJobTemplatesAPIEndpoint = "/api/v2/system_job_templates/"
+
+// systemJobTemplateSelectors are the arguments a system job template is looked up by.
+var systemJobTemplateSelectors = []string{"id", "name", "job_type"}
+
+type systemJobTemplate struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	JobType     string `json:"job_type"`
+}
+
+func dataSourceSystemJobTemplate() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSystemJobTemplateRead,
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: systemJobTemplateSelectors,
+				Description:  "Numeric ID of the system job template",
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: systemJobTemplateSelectors,
+				Description:  "Name of the system job template",
+			},
+			"job_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: systemJobTemplateSelectors,
+				ValidateFunc: validation.StringInSlice([]string{
+					"cleanup_jobs", "cleanup_activitystream", "cleanup_sessions", "cleanup_tokens",
+				}, false),
+				Description: "One of cleanup_jobs, cleanup_activitystream, cleanup_sessions, cleanup_tokens",
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the system job template",
+			},
+		},
+	}
+}
+
+func dataSourceSystemJobTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	params := map[string]string{}
+	if id, ok := d.GetOk("id"); ok {
+		params["id"] = strconv.Itoa(id.(int))
+	}
+	for _, key := range []string{"name", "job_type"} {
+		if v, ok := d.GetOk(key); ok {
+			params[key] = v.(string)
+		}
+	}
+
+	page := new(apiPage)
+	if err := apiGet(m, systemJobTemplatesAPIEndpoint, page, params); err != nil {
+		return buildDiagnosticsMessage(
+			"Get: Fail to fetch SystemJobTemplate",
+			"Fail to find the system job template got: %s",
+			err.Error(),
+		)
+	}
+	var templates []systemJobTemplate
+	if err := json.Unmarshal(page.Results, &templates); err != nil {
+		return buildDiagnosticsMessage(
+			"Get: Fail to fetch SystemJobTemplate",
+			"Fail to find the system job template got: %s",
+			err.Error(),
+		)
+	}
+	if len(templates) != 1 {
+		return buildDiagnosticsMessage(
+			"Get: find not exactly one Element",
+			"The Query Returns %d system job templates for %v",
+			len(templates), params,
+		)
+	}
+
+	template := templates[0]
+	d.Set("name", template.Name)
+	d.Set("description", template.Description)
+	d.Set("job_type", template.JobType)
+	d.SetId(strconv.Itoa(template.ID))
+	return diags
+}
@@ -3,9 +3,16 @@ package awx
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	awx "github.com/mrcrilly/goawx/client"
@@ -41,20 +48,137 @@ func resourceJobTemplateDelete(ctx context.Context, d *schema.ResourceData, m in
 	return nil
 }
 func buildDiagCreateFail(tfMethode string, err error) diag.Diagnostics {
-	return buildDiagnosticsMessage(
+	return buildDiagAPIFail(
 		fmt.Sprintf("Unable to create %s", tfMethode),
+		err, nil,
 		"Unable to create %s got %s",
 		tfMethode, err.Error(),
 	)
 }
 func buildDiagUpdateFail(tfMethode string, id int, err error) diag.Diagnostics {
-	return buildDiagnosticsMessage(
+	return buildDiagAPIFail(
 		fmt.Sprintf("Unable to update %s", tfMethode),
+		err, nil,
 		"Unable to update %s with id %d: got %s",
 		tfMethode, id, err.Error(),
 	)
 }
 
+// fieldAttributes maps the field names used by the AWX API to the terraform
+// attribute names shared by most resources. An empty attribute name marks
+// errors not tied to a single attribute.
+var fieldAttributes = map[string]string{
+	"organization":          "organisation_id",
+	"inventory":             "inventory_id",
+	"project":               "project_id",
+	"credential":            "credential_id",
+	"source_project":        "source_project_id",
+	"unified_job_template":  "unified_job_template_id",
+	"workflow_job_template": "workflow_job_template_id",
+	"target_credential":     "target",
+	"source_credential":     "source",
+	"non_field_errors":      "",
+	"__all__":               "",
+	"detail":                "",
+}
+
+type fieldError struct {
+	Field   string
+	Message string
+}
+
+var fieldErrorPattern = regexp.MustCompile(`(?m)^- (\w+): \[(.*)\]$`)
+
+// parseFieldErrors extracts the per field validation errors from the error
+// goawx returns for a 400 response. goawx flattens the JSON body, e.g.
+// {"playbook": ["Playbook not found for project."]}, into "- playbook: [...]" lines.
+func parseFieldErrors(err error) []fieldError {
+	if err == nil || !strings.HasPrefix(err.Error(), "Errors:") {
+		return nil
+	}
+	var fieldErrors []fieldError
+	for _, match := range fieldErrorPattern.FindAllStringSubmatch(err.Error(), -1) {
+		fieldErrors = append(fieldErrors, fieldError{Field: match[1], Message: match[2]})
+	}
+	sort.Slice(fieldErrors, func(i, j int) bool {
+		return fieldErrors[i].Field < fieldErrors[j].Field
+	})
+	return fieldErrors
+}
+
+// buildDiagAPIFail returns one diagnostic per field when AWX rejected the
+// request with field validation errors, with AttributePath pointing at the
+// offending argument. attributes overrides fieldAttributes for the resource,
+// fields the resource has no attribute for become resource-level diagnostics
+// through scopeDiagnosticsToSchema.
+// Any other error is reported as a single diagnostic built from diagDetails.
+// diagDetails ends with err.Error() in detailsVars, for field errors the
+// message of the field takes its place, the detail still names the object.
+func buildDiagAPIFail(diagSummary string, err error, attributes map[string]string, diagDetails string, detailsVars ...interface{}) diag.Diagnostics {
+	fieldErrors := parseFieldErrors(err)
+	if len(fieldErrors) == 0 {
+		return buildDiagnosticsMessage(diagSummary, diagDetails, detailsVars...)
+	}
+
+	var diags diag.Diagnostics
+	for _, fieldErr := range fieldErrors {
+		detail := fmt.Sprintf("AWX rejected %s: %s", fieldErr.Field, fieldErr.Message)
+		if last := len(detailsVars) - 1; last >= 0 && detailsVars[last] == err.Error() {
+			vars := append(append([]interface{}{}, detailsVars[:last]...), detail)
+			detail = fmt.Sprintf(diagDetails, vars...)
+		}
+		diagnostic := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  diagSummary,
+			Detail:   detail,
+		}
+		if attribute := attributeForField(fieldErr.Field, attributes); attribute != "" {
+			diagnostic.AttributePath = cty.GetAttrPath(attribute)
+		}
+		diags = append(diags, diagnostic)
+	}
+	return diags
+}
+
+// scopeDiagnosticsToSchema makes the diagnostics of r resource-level when
+// they point at an attribute r doesn't have. buildDiagAPIFail maps unknown
+// AWX fields to attributes of the same name, the detail names the field.
+func scopeDiagnosticsToSchema(r *schema.Resource) {
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			diags := f(ctx, d, m)
+			for i := range diags {
+				if len(diags[i].AttributePath) == 0 {
+					continue
+				}
+				if step, ok := diags[i].AttributePath[0].(cty.GetAttrStep); ok {
+					if _, ok := r.Schema[step.Name]; !ok {
+						diags[i].AttributePath = nil
+					}
+				}
+			}
+			return diags
+		}
+	}
+	r.CreateContext = wrap(r.CreateContext)
+	r.ReadContext = wrap(r.ReadContext)
+	r.UpdateContext = wrap(r.UpdateContext)
+	r.DeleteContext = wrap(r.DeleteContext)
+}
+
+func attributeForField(field string, attributes map[string]string) string {
+	if attribute, ok := attributes[field]; ok {
+		return attribute
+	}
+	if attribute, ok := fieldAttributes[field]; ok {
+		return attribute
+	}
+	return field
+}
+
 func buildDiagNotFoundFail(tfMethode string, id int, err error) diag.Diagnostics {
 	return buildDiagnosticsMessage(
 		fmt.Sprintf("Unable to fetch %s", tfMethode),
@@ -63,6 +187,66 @@ func buildDiagNotFoundFail(tfMethode string, id int, err error) diag.Diagnostics
 	)
 }
 
+// apiError carries the HTTP status code of a failed AWX API call. goawx only
+// returns untyped errors, the status code is taken from their message.
+type apiError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *apiError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *apiError) Unwrap() error {
+	return e.Err
+}
+
+var apiErrorStatusPattern = regexp.MustCompile(`responsed with (\d{3})`)
+
+// asAPIError returns the typed form of an error returned by goawx, or false
+// when err doesn't come from an AWX API response.
+func asAPIError(err error) (*apiError, bool) {
+	if err == nil {
+		return nil, false
+	}
+	var typed *apiError
+	if errors.As(err, &typed) {
+		return typed, true
+	}
+	match := apiErrorStatusPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return nil, false
+	}
+	statusCode, _ := strconv.Atoi(match[1])
+	return &apiError{StatusCode: statusCode, Err: err}, true
+}
+
+// isNotFound reports whether err is a 404 response of the AWX API.
+func isNotFound(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// removeResourceFromState is used by Read when the object was deleted outside
+// of terraform, the next plan re-creates it.
+func removeResourceFromState(d *schema.ResourceData, tfElement string, id int) diag.Diagnostics {
+	log.Printf("[WARN] %s with id %d not found in AWX, removing it from the state", tfElement, id)
+	d.SetId("")
+	return nil
+}
+
+// isConfigured tells whether key is set in the configuration, also when it is
+// set to the zero value of its type like verbosity = 0.
+func isConfigured(d *schema.ResourceData, key string) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		_, ok := d.GetOk(key)
+		return ok
+	}
+	return !config.GetAttr(key).IsNull()
+}
+
 func buildDiagDeleteFail(tfMethode, details string) diag.Diagnostics {
 	return buildDiagnosticsMessage(
 		buildDiagDeleteFailSummary(tfMethode),
@@ -99,6 +283,12 @@ func buildDiagnosticsMessage(diagSummary, diagDetails string, detailsVars ...int
 	return diags
 }
 
+// credentialFieldAttributes maps the AWX credential fields of the typed
+// credential resources, the credential type is set by the resource itself.
+var credentialFieldAttributes = map[string]string{
+	"credential_type": "",
+}
+
 func CredentialsServiceDeleteByID(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -151,3 +341,61 @@ func normalizeYamlOk(s interface{}) (string, bool) {
 	b, _ := yaml.Marshal(j)
 	return string(b[:]), true
 }
+
+// expandExtraVars decodes extra variables given in JSON or YAML, for the
+// endpoints that take them as an object instead of a string.
+func expandExtraVars(s string) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	if strings.TrimSpace(s) == "" {
+		return result, nil
+	}
+	if err := json.Unmarshal([]byte(s), &result); err == nil {
+		return result, nil
+	}
+	var y map[interface{}]interface{}
+	if err := yaml.Unmarshal([]byte(s), &y); err != nil {
+		return nil, fmt.Errorf("extra variables are neither a JSON nor a YAML object: %s", err)
+	}
+	return convertYamlValue(y).(map[string]interface{}), nil
+}
+
+// normalizeExtraVars returns the JSON or YAML extra variables s as canonical
+// JSON, like the API returns them, or s if it can't be parsed.
+func normalizeExtraVars(s interface{}) string {
+	vars, err := expandExtraVars(s.(string))
+	if err != nil {
+		return s.(string)
+	}
+	if len(vars) == 0 {
+		return ""
+	}
+	b, _ := json.Marshal(vars)
+	return string(b)
+}
+
+// validateExtraVars checks that extra variables are a JSON or YAML object.
+func validateExtraVars(v interface{}, k string) ([]string, []error) {
+	if _, err := expandExtraVars(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %s", k, err)}
+	}
+	return nil, nil
+}
+
+// convertYamlValue turns the maps decoded by yaml into maps JSON can encode.
+func convertYamlValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			result[fmt.Sprintf("%v", key)] = convertYamlValue(item)
+		}
+		return result
+	case []interface{}:
+		for i, item := range value {
+			value[i] = convertYamlValue(item)
+		}
+		return value
+	default:
+		return value
+	}
+}
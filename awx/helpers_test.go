@@ -0,0 +1,180 @@
+package awx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+// goawxStatusError builds the error CheckResponse of goawx returns for a
+// response outside of 2xx.
+func goawxStatusError(statusCode int) error {
+	resp := &http.Response{Status: fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)), StatusCode: statusCode}
+	return fmt.Errorf("responsed with %d, resp: %v", resp.StatusCode, resp)
+}
+
+// newGoawxTestClient returns a goawx client of a server answering every
+// request but the ping with status and body.
+func newGoawxTestClient(t *testing.T, status int, body string) *awx.AWX {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/ping/" {
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	client, err := awx.NewAWX(srv.URL, "admin", "password", srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestParseFieldErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		fields []fieldError
+	}{
+		{name: "nil"},
+		{
+			name:   "single field",
+			err:    errors.New("Errors:\n- playbook: [Playbook not found for project.]"),
+			fields: []fieldError{{Field: "playbook", Message: "Playbook not found for project."}},
+		},
+		{
+			name: "fields are sorted",
+			err:  errors.New("Errors:\n- name: [This field may not be blank.]\n- inventory: [Invalid pk \"5\" - object does not exist.]"),
+			fields: []fieldError{
+				{Field: "inventory", Message: `Invalid pk "5" - object does not exist.`},
+				{Field: "name", Message: "This field may not be blank."},
+			},
+		},
+		{
+			// goawx prints the messages of a field separated by spaces.
+			name:   "several messages of a field",
+			err:    errors.New("Errors:\n- __all__: [Cannot assign multiple credentials of the same type. A credential is required.]"),
+			fields: []fieldError{{Field: "__all__", Message: "Cannot assign multiple credentials of the same type. A credential is required."}},
+		},
+		{
+			// goawx decodes the body into map[string][]string, other bodies leave no field.
+			name: "body without field lists",
+			err:  errors.New("Errors:"),
+		},
+		{name: "status error", err: goawxStatusError(http.StatusForbidden)},
+		{name: "field list outside of a 400 error", err: errors.New("unexpected:\n- name: [taken]")},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if fields := parseFieldErrors(c.err); !reflect.DeepEqual(fields, c.fields) {
+				t.Fatalf("expected %v, got %v", c.fields, fields)
+			}
+		})
+	}
+}
+
+func TestParseFieldErrorsOfGoawx(t *testing.T) {
+	client := newGoawxTestClient(t, http.StatusBadRequest, `{"playbook":["Playbook not found for project."],"inventory":["This field is required."]}`)
+
+	_, err := client.JobTemplateService.CreateJobTemplate(map[string]interface{}{
+		"name": "a", "job_type": "run", "inventory": 1, "project": 2,
+	}, map[string]string{})
+	expected := []fieldError{
+		{Field: "inventory", Message: "This field is required."},
+		{Field: "playbook", Message: "Playbook not found for project."},
+	}
+	if fields := parseFieldErrors(err); !reflect.DeepEqual(fields, expected) {
+		t.Fatalf("expected %v from %q, got %v", expected, err, fields)
+	}
+}
+
+func TestBuildDiagAPIFail(t *testing.T) {
+	err := errors.New("Errors:\n- playbook: [Playbook not found for project.]\n- organization: [Invalid pk \"9\" - object does not exist.]\n- __all__: [Denied.]")
+	diags := buildDiagAPIFail("Unable to update JobTemplate", err, map[string]string{"playbook": "playbook_path"},
+		"Unable to update JobTemplate with id %d: got %s", 5, err.Error())
+
+	expected := []struct {
+		detail string
+		path   cty.Path
+	}{
+		{detail: "Unable to update JobTemplate with id 5: got AWX rejected __all__: Denied."},
+		{detail: `Unable to update JobTemplate with id 5: got AWX rejected organization: Invalid pk "9" - object does not exist.`, path: cty.GetAttrPath("organisation_id")},
+		{detail: "Unable to update JobTemplate with id 5: got AWX rejected playbook: Playbook not found for project.", path: cty.GetAttrPath("playbook_path")},
+	}
+	if len(diags) != len(expected) {
+		t.Fatalf("expected %d diagnostics, got %v", len(expected), diags)
+	}
+	for i, e := range expected {
+		if diags[i].Summary != "Unable to update JobTemplate" || diags[i].Detail != e.detail || !diags[i].AttributePath.Equals(e.path) {
+			t.Fatalf("diagnostic %d: expected %q at %#v, got %q at %#v", i, e.detail, e.path, diags[i].Detail, diags[i].AttributePath)
+		}
+	}
+
+	err = goawxStatusError(http.StatusInternalServerError)
+	diags = buildDiagAPIFail("Unable to update JobTemplate", err, nil, "Unable to update JobTemplate with id %d: got %s", 5, err.Error())
+	if len(diags) != 1 || diags[0].AttributePath != nil || !strings.HasPrefix(diags[0].Detail, "Unable to update JobTemplate with id 5: got responsed with 500") {
+		t.Fatalf("expected a single diagnostic of the error, got %v", diags)
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		notFound bool
+	}{
+		{name: "nil"},
+		{name: "404", err: goawxStatusError(http.StatusNotFound), notFound: true},
+		{name: "wrapped 404", err: fmt.Errorf("reading job 7: %w", goawxStatusError(http.StatusNotFound)), notFound: true},
+		{name: "typed 404", err: &apiError{StatusCode: http.StatusNotFound, Err: errors.New("not found")}, notFound: true},
+		{name: "403", err: goawxStatusError(http.StatusForbidden)},
+		{name: "500", err: goawxStatusError(http.StatusInternalServerError)},
+		{name: "400", err: errors.New("Errors:\n- detail: [Not found.]")},
+		{name: "connection error", err: errors.New(`Get "https://awx.example.com/api/v2/hosts/404/": dial tcp: connection refused`)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if notFound := isNotFound(c.err); notFound != c.notFound {
+				t.Fatalf("expected isNotFound %t for %v, got %t", c.notFound, c.err, notFound)
+			}
+		})
+	}
+}
+
+func TestIsNotFoundOfGoawx(t *testing.T) {
+	cases := []struct {
+		status   int
+		notFound bool
+	}{
+		{status: http.StatusNotFound, notFound: true},
+		{status: http.StatusForbidden},
+		{status: http.StatusBadGateway},
+	}
+	for _, c := range cases {
+		t.Run(http.StatusText(c.status), func(t *testing.T) {
+			client := newGoawxTestClient(t, c.status, `{"detail":"Not found."}`)
+
+			_, err := client.JobTemplateService.GetJobTemplateByID(404, map[string]string{})
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if notFound := isNotFound(err); notFound != c.notFound {
+				t.Fatalf("expected isNotFound %t for %q, got %t", c.notFound, err, notFound)
+			}
+			if apiErr, ok := asAPIError(err); !ok || apiErr.StatusCode != c.status {
+				t.Fatalf("expected status %d from %q, got %v", c.status, err, apiErr)
+			}
+		})
+	}
+}
@@ -2,19 +2,16 @@ package awx
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	awx "github.com/mrcrilly/goawx/client"
-	"net/http"
-	"os"
-	"path/filepath"
+	"log"
 )
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"hostname": &schema.Schema{
 				Type:        schema.TypeString,
@@ -30,44 +27,172 @@ func Provider() *schema.Provider {
 			"username": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("AWX_USERNAME", "admin"),
+				DefaultFunc: schema.EnvDefaultFunc("AWX_USERNAME", defaultUsername),
 			},
 			"password": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
 				Sensitive:   true,
-				DefaultFunc: schema.EnvDefaultFunc("AWX_PASSWORD", "password"),
+				DefaultFunc: schema.EnvDefaultFunc("AWX_PASSWORD", defaultPassword),
 			},
-			"client_cert": &schema.Schema{
+			"token": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Client certificate to use for mTLS validation. Must be provided along with client-key and ca-cert for mTLS to be used.",
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("AWX_TOKEN", ""),
+				Description: "OAuth2 token used to authenticate against the AWX API. Takes precedence over username and password.",
 			},
-			"client_key": &schema.Schema{
+			"oauth2_token_exchange": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Exchange username and password for a personal access token at configure time. The token is revoked when the provider exits.",
+			},
+			"oauth2_token_scope": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "write",
+				ValidateFunc: validation.StringInSlice([]string{"read", "write"}, false),
+				Description:  "Scope of the exchanged personal access token. One of: read, write",
+			},
+			"request_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Timeout in seconds for a single attempt of an API request, retries get a new timeout and the waits between them don't count. 0 disables the timeout",
+			},
+			"proxy_url": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Client key to use for mTLS validation. Must be provided along with client-cert and ca-cert for mTLS to be used",
+				Default:     "",
+				Description: "URL of the proxy used for API calls. The HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used when empty",
 			},
-			"ca_cert": &schema.Schema{
+			"max_idle_conns": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of idle keep-alive connections to the AWX API, 0 keeps the default of the Go HTTP client of 2 idle connections",
+			},
+			"enable_http2": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Negotiate HTTP/2 with the AWX API when it is supported",
+			},
+			"max_retries": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of retries of API requests failing with a transient error, 0 disables retries",
+			},
+			"retry_wait_min": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Wait in seconds before the first retry, doubled for every following retry",
+			},
+			"retry_wait_max": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum wait in seconds between two retries, unless AWX asks for a longer wait with Retry-After",
+			},
+			"max_requests_per_second": &schema.Schema{
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Maximum number of API requests per second sent by all resources and data sources, 0 disables the limit",
+			},
+			"max_concurrent_requests": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of API requests in flight at the same time, 0 disables the limit",
+			},
+			"client_cert": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"client_cert_file"},
+				Description:   "PEM encoded client certificate to use for mTLS validation. Must be provided along with client_key. Can be combined with insecure.",
+			},
+			"client_cert_file": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"client_cert"},
+				Description:   "Path to a PEM encoded client certificate to use for mTLS validation",
+			},
+			"client_key": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"client_key_file"},
+				Description:   "PEM encoded client key to use for mTLS validation. Must be provided along with client_cert",
+			},
+			"client_key_file": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"client_key"},
+				Description:   "Path to a PEM encoded client key to use for mTLS validation",
+			},
+			"client_key_passphrase": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "CA certificate to use for mTLS validation. Must be provided along with client-cert and client-key for mTLS to be used",
+				Sensitive:   true,
+				Description: "Passphrase of an encrypted PKCS#8 client key",
+			},
+			"ca_cert": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+				Description:   "PEM encoded CA certificate used to verify the AWX API, in addition to the system CAs. Can be used without client_cert and client_key",
+			},
+			"ca_cert_file": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert"},
+				Description:   "Path to a PEM encoded CA certificate used to verify the AWX API",
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
+			"awx_ad_hoc_command":                     resourceAdHocCommand(),
+			"awx_bulk_job_launch":                    resourceBulkJobLaunch(),
+			"awx_credential":                         resourceCredential(),
+			"awx_credential_aws":                     resourceCredentialAWS(),
 			"awx_credential_azure_key_vault":         resourceCredentialAzureKeyVault(),
+			"awx_credential_azure_rm":                resourceCredentialAzureRM(),
 			"awx_credential_google_compute_engine":   resourceCredentialGoogleComputeEngine(),
+			"awx_credential_hashicorp_vault_lookup":  resourceCredentialHashicorpVaultLookup(),
+			"awx_credential_hashicorp_vault_ssh":     resourceCredentialHashicorpVaultSSH(),
 			"awx_credential_input_source":            resourceCredentialInputSource(),
 			"awx_credential_machine":                 resourceCredentialMachine(),
+			"awx_credential_network":                 resourceCredentialNetwork(),
+			"awx_credential_openstack":               resourceCredentialOpenStack(),
+			"awx_credential_satellite":               resourceCredentialSatellite(),
 			"awx_credential_scm":                     resourceCredentialSCM(),
+			"awx_credential_type":                    resourceCredentialType(),
+			"awx_credential_vmware_vcenter":          resourceCredentialVMwareVCenter(),
 			"awx_host":                               resourceHost(),
 			"awx_inventory_group":                    resourceInventoryGroup(),
 			"awx_inventory_source":                   resourceInventorySource(),
 			"awx_inventory":                          resourceInventory(),
+			"awx_job_launch":                         resourceJobLaunch(),
+			"awx_job_template_callback":              resourceJobTemplateCallback(),
 			"awx_job_template_credential":            resourceJobTemplateCredentials(),
 			"awx_job_template":                       resourceJobTemplate(),
 			"awx_organization":                       resourceOrganization(),
 			"awx_project":                            resourceProject(),
+			"awx_system_job_launch":                  resourceSystemJobLaunch(),
+			"awx_system_job_template_schedule":       resourceSystemJobTemplateSchedule(),
+			"awx_workflow_approval":                  resourceWorkflowApproval(),
+			"awx_workflow_job_launch":                resourceWorkflowJobLaunch(),
+			"awx_workflow_job_template_graph":        resourceWorkflowJobTemplateGraph(),
 			"awx_workflow_job_template_node_allways": resourceWorkflowJobTemplateNodeAllways(),
 			"awx_workflow_job_template_node_failure": resourceWorkflowJobTemplateNodeFailure(),
 			"awx_workflow_job_template_node_success": resourceWorkflowJobTemplateNodeSuccess(),
@@ -77,16 +202,27 @@ func Provider() *schema.Provider {
 		DataSourcesMap: map[string]*schema.Resource{
 			"awx_credential_azure_key_vault": dataSourceCredentialAzure(),
 			"awx_credential":                 dataSourceCredentialByName(),
+			"awx_credential_type":            dataSourceCredentialType(),
 			"awx_execution_environment":      dataSourceExecutionEnvironmentByName(),
 			"awx_inventory_group":            dataSourceInventoryGroup(),
 			"awx_inventory":                  dataSourceInventory(),
+			"awx_job_host_summaries":         dataSourceJobHostSummaries(),
 			"awx_job_template":               dataSourceJobTemplate(),
+			"awx_job":                        dataSourceJob(),
 			"awx_organization":               dataSourceOrganization(),
 			"awx_project":                    dataSourceProject(),
+			"awx_system_job_template":        dataSourceSystemJobTemplate(),
 			"awx_workflow_job_template":      dataSourceWorkflowJobTemplate(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
+	for _, r := range p.ResourcesMap {
+		scopeDiagnosticsToSchema(r)
+	}
+	for _, r := range p.DataSourcesMap {
+		scopeDiagnosticsToSchema(r)
+	}
+	return p
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -94,85 +230,72 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	username := d.Get("username").(string)
 	password := d.Get("password").(string)
 
-	clientCertPEM, clientCertPEMExists := d.GetOk("client_cert")
-	clientKeyPEM, clientKeyPEMExists := d.GetOk("client_key")
-	caCertPEM, caCertPEMExists := d.GetOk("ca_cert")
+	client, err := newHTTPClient(d)
+	if err != nil {
+		var diags diag.Diagnostics
+		return nil, append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid HTTP client config",
+			Detail:   fmt.Sprintf("The provided HTTP client config is invalid: %s", err),
+		})
+	}
 
-	client := http.DefaultClient
-	if d.Get("insecure").(bool) {
-		client.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	authMethod := authMethodBasic
+	token := d.Get("token").(string)
+	if token != "" {
+		authMethod = authMethodToken
+		if username != defaultUsername || password != defaultPassword {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Token authentication takes precedence",
+				Detail:   "Both a token and username/password are configured, the AWX API is accessed with the token and the username/password are ignored",
+			})
 		}
-	} else if clientCertPEMExists && clientKeyPEMExists && caCertPEMExists {
-		transportTlsConfig, err := generateMtlsConfig(
-			clientCertPEM.(string),
-			clientKeyPEM.(string),
-			caCertPEM.(string))
-
-		if err == nil {
-			client.Transport = &http.Transport{
-				TLSClientConfig: transportTlsConfig,
-			}
-		} else {
-			var diags diag.Diagnostics
-			return nil, append(diags, diag.Diagnostic{
+	} else if d.Get("oauth2_token_exchange").(bool) {
+		pat, err := createPersonalAccessToken(hostname, username, password, d.Get("oauth2_token_scope").(string), client)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
-				Summary:  "Invalid mTLS config",
-				Detail:   fmt.Sprintf("The provided mTLS config is invalid: %s", err),
+				Summary:  "Unable to exchange credentials for an OAuth2 token",
+				Detail:   fmt.Sprintf("Unable to create a personal access token for user %s: %s", username, err),
 			})
+			return nil, diags
 		}
+		authMethod = authMethodTokenExchange
+		token = pat.Token
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Authenticating with an exchanged OAuth2 token",
+			Detail:   fmt.Sprintf("Personal access token %d of user %s was created in AWX, it is revoked when the provider stops", pat.ID, username),
+		})
+		revokeClient := newBearerTokenClient(client, token)
+		registerShutdownHook(func() {
+			if err := revokePersonalAccessToken(hostname, pat, revokeClient); err != nil {
+				log.Printf("[WARN] Unable to revoke personal access token %d: %s", pat.ID, err)
+			}
+		})
+	}
+	if token != "" {
+		client = newBearerTokenClient(client, token)
 	}
+	log.Printf("[INFO] Authenticating against %s with %s", hostname, authMethod)
 
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
 	c, err := awx.NewAWX(hostname, username, password, client)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create AWX client",
-			Detail:   "Unable to auth user against AWX API: check the hostname, username and password",
+			Detail:   fmt.Sprintf("Unable to auth against AWX API using %s: check the hostname and credentials, got %s", authMethod, err),
 		})
 		return nil, diags
 	}
+	registerRequester(c, &awx.Requester{
+		Base:      hostname,
+		BasicAuth: &awx.BasicAuth{Username: username, Password: password},
+		Client:    client,
+	})
 
 	return c, diags
 }
-
-// This method also writes the mTLS certificates to disk - to allow them to be used for other calls to
-// AWX
-func generateMtlsConfig(clientCertPEM string, clientKeyPEM string, caCertPEM string) (*tls.Config, error) {
-	clientCertPEMBlock := []byte(clientCertPEM)
-	clientKeyPEMBlock := []byte(clientKeyPEM)
-	caCertPEMBlock := []byte(caCertPEM)
-
-	tempCertificatePath := "/wrk/terraform-provider-awx"
-	os.MkdirAll(tempCertificatePath, 0700)
-
-	writeByteArrayToFile(clientCertPEMBlock, filepath.Join(tempCertificatePath, "tls.crt"))
-	writeByteArrayToFile(clientKeyPEMBlock, filepath.Join(tempCertificatePath, "tls.key"))
-	writeByteArrayToFile(caCertPEMBlock, filepath.Join(tempCertificatePath, "ca.crt"))
-
-	cert, err := tls.X509KeyPair(clientCertPEMBlock, clientKeyPEMBlock)
-	if err != nil {
-		return nil, err
-	}
-
-	caCertPool, _ := x509.SystemCertPool()
-	if caCertPool == nil {
-		caCertPool = x509.NewCertPool()
-	}
-	caCertPool.AppendCertsFromPEM(caCertPEMBlock)
-
-	return &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		RootCAs:      caCertPool,
-	}, nil
-}
-
-func writeByteArrayToFile(byteArray []byte, filePath string) {
-	t, err := os.Create(filePath)
-	if err == nil {
-		t.Write(byteArray)
-		t.Close()
-	}
-}
@@ -0,0 +1,117 @@
+package awx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+const (
+	defaultUsername = "admin"
+	defaultPassword = "password"
+
+	authMethodBasic         = "basic authentication"
+	authMethodToken         = "token authentication"
+	authMethodTokenExchange = "an exchanged personal access token"
+
+	tokensAPIEndpoint = "/api/v2/tokens/"
+)
+
+var (
+	shutdownHooksMu sync.Mutex
+	shutdownHooks   []func()
+)
+
+// Shutdown runs the cleanup registered while configuring providers, like
+// revoking exchanged personal access tokens. Call it once the plugin server
+// has stopped.
+func Shutdown() {
+	shutdownHooksMu.Lock()
+	hooks := shutdownHooks
+	shutdownHooks = nil
+	shutdownHooksMu.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+func registerShutdownHook(hook func()) {
+	shutdownHooksMu.Lock()
+	defer shutdownHooksMu.Unlock()
+	shutdownHooks = append(shutdownHooks, hook)
+}
+
+// bearerTokenTransport authenticates every request with an OAuth2 token. goawx
+// always sets basic auth on its requests, the bearer token replaces it.
+type bearerTokenTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *bearerTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	authReq := req.Clone(req.Context())
+	authReq.Header.Set("Authorization", "Bearer "+t.token)
+	return base.RoundTrip(authReq)
+}
+
+func newBearerTokenClient(client *http.Client, token string) *http.Client {
+	return &http.Client{
+		Transport: &bearerTokenTransport{token: token, base: client.Transport},
+		Timeout:   client.Timeout,
+	}
+}
+
+type personalAccessToken struct {
+	ID    int    `json:"id"`
+	Token string `json:"token"`
+}
+
+// createPersonalAccessToken exchanges username and password for a personal
+// access token, a token without an application.
+func createPersonalAccessToken(hostname, username, password, scope string, client *http.Client) (*personalAccessToken, error) {
+	requester := &awx.Requester{
+		Base:      hostname,
+		BasicAuth: &awx.BasicAuth{Username: username, Password: password},
+		Client:    client,
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"description": "terraform-provider-awx",
+		"application": nil,
+		"scope":       scope,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(personalAccessToken)
+	resp, err := requester.PostJSON(tokensAPIEndpoint, bytes.NewReader(payload), result, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := awx.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	if result.Token == "" {
+		return nil, errors.New("the AWX API returned an empty token")
+	}
+	return result, nil
+}
+
+func revokePersonalAccessToken(hostname string, token *personalAccessToken, client *http.Client) error {
+	requester := &awx.Requester{Base: hostname, Client: client}
+	resp, err := requester.Delete(fmt.Sprintf("%s%d/", tokensAPIEndpoint, token.ID), nil, nil)
+	if err != nil {
+		return err
+	}
+	return awx.CheckResponse(resp)
+}
@@ -0,0 +1,222 @@
+package awx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+// requesters holds a raw requester for every configured provider, keyed by its
+// goawx client. goawx keeps its requester unexported, the raw requester reaches
+// the API endpoints goawx has no service for with the same HTTP client and
+// credentials.
+var requesters sync.Map
+
+func registerRequester(client *awx.AWX, requester *awx.Requester) {
+	requesters.Store(client, requester)
+}
+
+// requesterFor returns the raw requester of the provider meta m.
+func requesterFor(m interface{}) (*awx.Requester, error) {
+	client := m.(*awx.AWX)
+	requester, ok := requesters.Load(client)
+	if !ok {
+		return nil, fmt.Errorf("no API requester is registered for the provider")
+	}
+	return requester.(*awx.Requester), nil
+}
+
+// apiGet reads endpoint into result, the response status is checked.
+func apiGet(m interface{}, endpoint string, result interface{}, query map[string]string) error {
+	requester, err := requesterFor(m)
+	if err != nil {
+		return err
+	}
+	resp, err := requester.GetJSON(endpoint, result, query)
+	if err != nil {
+		return err
+	}
+	return awx.CheckResponse(resp)
+}
+
+// apiGetTextTail streams endpoint as plain text, like the stdout of a job, and
+// keeps only its last maxBytes. cut tells whether the start was dropped.
+func apiGetTextTail(m interface{}, endpoint string, query map[string]string, maxBytes int) (string, bool, error) {
+	requester, err := requesterFor(m)
+	if err != nil {
+		return "", false, err
+	}
+	u, err := url.Parse(requester.Base + endpoint)
+	if err != nil {
+		return "", false, err
+	}
+	values := u.Query()
+	for key, value := range query {
+		values.Set(key, value)
+	}
+	u.RawQuery = values.Encode()
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", false, err
+	}
+	if requester.BasicAuth != nil {
+		req.SetBasicAuth(requester.BasicAuth.Username, requester.BasicAuth.Password)
+	}
+	resp, err := requester.Client.Do(req)
+	if err != nil {
+		return "", false, err
+	}
+	defer resp.Body.Close()
+	if err := awx.CheckResponse(resp); err != nil {
+		return "", false, err
+	}
+	tail, cut, err := readTail(resp.Body, maxBytes)
+	return string(tail), cut, err
+}
+
+// readTail reads r to its end and keeps only the last maxBytes, the memory
+// used stays within twice maxBytes however long r is.
+func readTail(r io.Reader, maxBytes int) ([]byte, bool, error) {
+	buf := make([]byte, 0, 2*maxBytes)
+	chunk := make([]byte, 32*1024)
+	cut := false
+	for {
+		n, err := r.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		if len(buf) > 2*maxBytes {
+			buf = append(buf[:0], buf[len(buf)-maxBytes:]...)
+			cut = true
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	if len(buf) > maxBytes {
+		buf = buf[len(buf)-maxBytes:]
+		cut = true
+	}
+	return buf, cut, nil
+}
+
+// apiPage is a page of an AWX list endpoint.
+type apiPage struct {
+	Next    string          `json:"next"`
+	Results json.RawMessage `json:"results"`
+}
+
+// apiGetAll reads every page of the list endpoint and hands the results of
+// each page to appendResults.
+func apiGetAll(m interface{}, endpoint string, query map[string]string, appendResults func(results json.RawMessage) error) error {
+	pageQuery := map[string]string{"page_size": "200"}
+	for key, value := range query {
+		pageQuery[key] = value
+	}
+	for page := 1; ; page++ {
+		pageQuery["page"] = strconv.Itoa(page)
+		result := new(apiPage)
+		if err := apiGet(m, endpoint, result, pageQuery); err != nil {
+			return err
+		}
+		if err := appendResults(result.Results); err != nil {
+			return err
+		}
+		if result.Next == "" {
+			return nil
+		}
+	}
+}
+
+// apiPost sends payload as JSON to endpoint and reads the response into result,
+// result can be nil.
+func apiPost(m interface{}, endpoint string, payload interface{}, result interface{}) error {
+	requester, err := requesterFor(m)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		result = &map[string]interface{}{}
+	}
+	resp, err := requester.PostJSON(endpoint, bytes.NewReader(body), result, nil)
+	if err != nil {
+		return err
+	}
+	return awx.CheckResponse(resp)
+}
+
+// apiPatch sends the changed fields in payload as JSON to endpoint and reads
+// the response into result, result can be nil.
+func apiPatch(m interface{}, endpoint string, payload interface{}, result interface{}) error {
+	requester, err := requesterFor(m)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		result = &map[string]interface{}{}
+	}
+	resp, err := requester.PatchJSON(endpoint, bytes.NewReader(body), result, nil)
+	if err != nil {
+		return err
+	}
+	return awx.CheckResponse(resp)
+}
+
+// apiPostLocation sends payload as JSON to endpoint with the extra headers and
+// returns the Location header of the response, for endpoints that answer
+// without a body.
+func apiPostLocation(m interface{}, endpoint string, payload interface{}, headers map[string]string) (string, error) {
+	requester, err := requesterFor(m)
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	ar := awx.NewAPIRequest("POST", endpoint, bytes.NewReader(body))
+	ar.SetHeader("Content-Type", "application/json")
+	for key, value := range headers {
+		ar.SetHeader(key, value)
+	}
+	resp, err := requester.Do(ar, &map[string]interface{}{})
+	if err != nil {
+		return "", err
+	}
+	if err := awx.CheckResponse(resp); err != nil {
+		return "", err
+	}
+	return resp.Header.Get("Location"), nil
+}
+
+// apiDelete deletes endpoint, a missing object is not an error.
+func apiDelete(m interface{}, endpoint string) error {
+	requester, err := requesterFor(m)
+	if err != nil {
+		return err
+	}
+	resp, err := requester.Delete(endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return awx.CheckResponse(resp)
+}
@@ -0,0 +1,34 @@
+package awx
+
+import (
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReadTail(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		maxBytes int
+		tail     string
+		cut      bool
+	}{
+		{name: "empty", input: "", maxBytes: 4, tail: "", cut: false},
+		{name: "shorter", input: "abc", maxBytes: 4, tail: "abc", cut: false},
+		{name: "exact", input: "abcd", maxBytes: 4, tail: "abcd", cut: false},
+		{name: "longer", input: "abcdefghij", maxBytes: 4, tail: "ghij", cut: true},
+		{name: "much longer", input: strings.Repeat("x", 100000) + "recap", maxBytes: 5, tail: "recap", cut: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tail, cut, err := readTail(iotest.OneByteReader(strings.NewReader(c.input)), c.maxBytes)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(tail) != c.tail || cut != c.cut {
+				t.Fatalf("expected %q, %t, got %q, %t", c.tail, c.cut, tail, cut)
+			}
+		})
+	}
+}
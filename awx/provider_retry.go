@@ -0,0 +1,160 @@
+package awx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryTransport retries requests that failed with a transient error. Idempotent
+// requests are retried on connection errors and on 429, 502, 503 and 504
+// responses. Other requests, like the POST creating an object, are only retried
+// on connection errors, AWX might already have processed them otherwise. Every
+// attempt gets its own timeout, the waits between attempts don't count.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	waitMin    time.Duration
+	waitMax    time.Duration
+	timeout    time.Duration
+}
+
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, timedOut, err := t.roundTripAttempt(attemptReq)
+		if timedOut && !idempotentMethods[req.Method] {
+			// AWX might be processing the request, it is not sent again.
+			return resp, err
+		}
+		if !t.shouldRetry(req, resp, err, attempt) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if err != nil {
+			log.Printf("[WARN] %s %s failed: %s, retry %d/%d in %s", req.Method, req.URL, err, attempt+1, t.maxRetries, wait)
+		} else {
+			log.Printf("[WARN] %s %s responded with %d, retry %d/%d in %s", req.Method, req.URL, resp.StatusCode, attempt+1, t.maxRetries, wait)
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// roundTripAttempt sends req with the timeout of a single attempt, which runs
+// until the body of the response is closed. timedOut tells whether the attempt
+// failed because of the timeout.
+func (t *retryTransport) roundTripAttempt(req *http.Request) (*http.Response, bool, error) {
+	if t.timeout <= 0 {
+		resp, err := t.base.RoundTrip(req)
+		return resp, false, err
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		timedOut := errors.Is(ctx.Err(), context.DeadlineExceeded) && req.Context().Err() == nil
+		cancel()
+		return nil, timedOut, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, false, nil
+}
+
+// cancelOnCloseBody releases the timeout of an attempt once its response body
+// is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error, attempt int) bool {
+	if attempt >= t.maxRetries || req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The body can't be sent a second time.
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return idempotentMethods[req.Method] && retryableStatusCodes[resp.StatusCode]
+}
+
+// backoff doubles the wait for every attempt, bounded by waitMin and waitMax. A
+// Retry-After header sent by AWX or its ingress takes precedence.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	wait := t.waitMin
+	for i := 0; i < attempt && wait < t.waitMax; i++ {
+		wait *= 2
+	}
+	if wait > t.waitMax {
+		wait = t.waitMax
+	}
+	return wait
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
@@ -0,0 +1,298 @@
+package awx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestRetryTransport() *retryTransport {
+	return &retryTransport{
+		base:       http.DefaultTransport,
+		maxRetries: 2,
+		waitMin:    time.Millisecond,
+		waitMax:    2 * time.Millisecond,
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	cases := []struct {
+		name     string
+		method   string
+		statuses []int
+		status   int
+		attempts int32
+	}{
+		{name: "GET success", method: http.MethodGet, statuses: []int{200}, status: 200, attempts: 1},
+		{name: "GET 503 then success", method: http.MethodGet, statuses: []int{503, 200}, status: 200, attempts: 2},
+		{name: "GET 429 until exhausted", method: http.MethodGet, statuses: []int{429, 429, 429, 200}, status: 429, attempts: 3},
+		{name: "DELETE 502 then success", method: http.MethodDelete, statuses: []int{502, 204}, status: 204, attempts: 2},
+		{name: "PUT 504 then success", method: http.MethodPut, statuses: []int{504, 200}, status: 200, attempts: 2},
+		{name: "GET 500 is not retried", method: http.MethodGet, statuses: []int{500, 200}, status: 500, attempts: 1},
+		{name: "GET 404 is not retried", method: http.MethodGet, statuses: []int{404, 200}, status: 404, attempts: 1},
+		{name: "POST 503 is not retried", method: http.MethodPost, statuses: []int{503, 201}, status: 503, attempts: 1},
+		{name: "POST 429 is not retried", method: http.MethodPost, statuses: []int{429, 201}, status: 429, attempts: 1},
+		{name: "PATCH 503 is not retried", method: http.MethodPatch, statuses: []int{503, 200}, status: 503, attempts: 1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var attempts int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := atomic.AddInt32(&attempts, 1)
+				body, _ := io.ReadAll(r.Body)
+				if r.Method != http.MethodGet && r.Method != http.MethodDelete && string(body) != `{"name":"a"}` {
+					t.Errorf("attempt %d sent the body %q", attempt, body)
+				}
+				w.WriteHeader(c.statuses[attempt-1])
+			}))
+			defer srv.Close()
+
+			var body io.Reader
+			if c.method != http.MethodGet && c.method != http.MethodDelete {
+				body = strings.NewReader(`{"name":"a"}`)
+			}
+			req, _ := http.NewRequest(c.method, srv.URL, body)
+			resp, err := newTestRetryTransport().RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != c.status {
+				t.Fatalf("expected status %d, got %d", c.status, resp.StatusCode)
+			}
+			if attempts := atomic.LoadInt32(&attempts); attempts != c.attempts {
+				t.Fatalf("expected %d attempts, got %d", c.attempts, attempts)
+			}
+		})
+	}
+}
+
+func TestRetryTransportConnectionError(t *testing.T) {
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		t.Run(method, func(t *testing.T) {
+			var attempts int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attempts, 1) == 1 {
+					// Drop the connection without a response.
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					conn.Close()
+					return
+				}
+				body, _ := io.ReadAll(r.Body)
+				if method == http.MethodPost && string(body) != `{"name":"a"}` {
+					t.Errorf("the retry sent the body %q", body)
+				}
+			}))
+			defer srv.Close()
+
+			var body io.Reader
+			if method == http.MethodPost {
+				body = strings.NewReader(`{"name":"a"}`)
+			}
+			req, _ := http.NewRequest(method, srv.URL, body)
+			resp, err := newTestRetryTransport().RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp.Body.Close()
+			if attempts := atomic.LoadInt32(&attempts); attempts != 2 {
+				t.Fatalf("expected 2 attempts, got %d", attempts)
+			}
+		})
+	}
+}
+
+func TestRetryTransportTimeout(t *testing.T) {
+	cases := []struct {
+		method   string
+		attempts int32
+		err      bool
+	}{
+		{method: http.MethodGet, attempts: 2},
+		{method: http.MethodPost, attempts: 1, err: true},
+	}
+	for _, c := range cases {
+		t.Run(c.method, func(t *testing.T) {
+			var attempts int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attempts, 1) == 1 {
+					select {
+					case <-r.Context().Done():
+					case <-time.After(time.Second):
+					}
+				}
+			}))
+			defer srv.Close()
+
+			transport := newTestRetryTransport()
+			transport.timeout = 50 * time.Millisecond
+			req, _ := http.NewRequest(c.method, srv.URL, nil)
+			resp, err := transport.RoundTrip(req)
+			if c.err {
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Fatalf("expected the attempt to time out, got %v", err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				resp.Body.Close()
+			}
+			if attempts := atomic.LoadInt32(&attempts); attempts != c.attempts {
+				t.Fatalf("expected %d attempts, got %d", c.attempts, attempts)
+			}
+		})
+	}
+}
+
+func TestRetryTransportTimeoutCoversBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	transport := newTestRetryTransport()
+	transport.timeout = 50 * time.Millisecond
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+	if _, err := io.ReadAll(resp.Body); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected reading the body to time out, got %v", err)
+	}
+}
+
+func TestRetryTransportContextCanceled(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	start := time.Now()
+	_, err := newTestRetryTransport().RoundTrip(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the context error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("the canceled request waited %s for Retry-After", elapsed)
+	}
+	if attempts := atomic.LoadInt32(&attempts); attempts != 1 {
+		t.Fatalf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func newTestRequest(ctx context.Context, method string) *http.Request {
+	req, _ := http.NewRequestWithContext(ctx, method, "http://awx.example.com/api/v2/", nil)
+	return req
+}
+
+func TestShouldRetry(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cases := []struct {
+		name    string
+		req     *http.Request
+		status  int
+		err     error
+		attempt int
+		retry   bool
+	}{
+		{name: "GET 503", req: newTestRequest(context.Background(), http.MethodGet), status: 503, retry: true},
+		{name: "HEAD 429", req: newTestRequest(context.Background(), http.MethodHead), status: 429, retry: true},
+		{name: "GET 200", req: newTestRequest(context.Background(), http.MethodGet), status: 200},
+		{name: "GET 400", req: newTestRequest(context.Background(), http.MethodGet), status: 400},
+		{name: "POST 503", req: newTestRequest(context.Background(), http.MethodPost), status: 503},
+		{name: "POST connection error", req: newTestRequest(context.Background(), http.MethodPost), err: io.ErrUnexpectedEOF, retry: true},
+		{name: "GET last attempt", req: newTestRequest(context.Background(), http.MethodGet), status: 503, attempt: 2},
+		{name: "GET canceled", req: newTestRequest(canceled, http.MethodGet), err: context.Canceled},
+		{
+			name: "body without GetBody",
+			req:  &http.Request{Method: http.MethodPut, Body: io.NopCloser(strings.NewReader("a"))},
+			err:  io.ErrUnexpectedEOF,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var resp *http.Response
+			if c.err == nil {
+				resp = &http.Response{StatusCode: c.status}
+			}
+			if retry := newTestRetryTransport().shouldRetry(c.req, resp, c.err, c.attempt); retry != c.retry {
+				t.Fatalf("expected shouldRetry %t, got %t", c.retry, retry)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	transport := &retryTransport{waitMin: time.Second, waitMax: 8 * time.Second}
+	for attempt, wait := range []time.Duration{1, 2, 4, 8, 8, 8} {
+		if got := transport.backoff(attempt, nil); got != wait*time.Second {
+			t.Fatalf("attempt %d: expected %s, got %s", attempt, wait*time.Second, got)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": {"30"}}}
+	if got := transport.backoff(0, resp); got != 30*time.Second {
+		t.Fatalf("expected Retry-After to take precedence, got %s", got)
+	}
+	resp.Header.Set("Retry-After", "soon")
+	if got := transport.backoff(1, resp); got != 2*time.Second {
+		t.Fatalf("expected an invalid Retry-After to be ignored, got %s", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		min   time.Duration
+		max   time.Duration
+		ok    bool
+	}{
+		{name: "empty"},
+		{name: "seconds", value: "120", min: 2 * time.Minute, max: 2 * time.Minute, ok: true},
+		{name: "zero seconds", value: "0", ok: true},
+		{name: "negative seconds", value: "-1"},
+		{name: "garbage", value: "soon"},
+		{
+			name:  "HTTP date",
+			value: time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat),
+			min:   28 * time.Second,
+			max:   30 * time.Second,
+			ok:    true,
+		},
+		{name: "HTTP date in the past", value: "Wed, 21 Oct 2015 07:28:00 GMT", ok: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			wait, ok := parseRetryAfter(c.value)
+			if ok != c.ok {
+				t.Fatalf("expected ok %t, got %t", c.ok, ok)
+			}
+			if wait < c.min || wait > c.max {
+				t.Fatalf("expected a wait between %s and %s, got %s", c.min, c.max, wait)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package awx
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// throttleTransport limits the request rate with a token bucket and caps the
+// number of requests in flight with a semaphore. A single instance is shared by
+// every resource and data source of a provider, large applies can't flood AWX.
+type throttleTransport struct {
+	base      http.RoundTripper
+	limiter   *tokenBucket
+	semaphore chan struct{}
+}
+
+func newThrottleTransport(base http.RoundTripper, requestsPerSecond float64, maxConcurrent int) http.RoundTripper {
+	if requestsPerSecond <= 0 && maxConcurrent <= 0 {
+		return base
+	}
+	t := &throttleTransport{base: base}
+	if requestsPerSecond > 0 {
+		t.limiter = newTokenBucket(requestsPerSecond)
+	}
+	if maxConcurrent > 0 {
+		t.semaphore = make(chan struct{}, maxConcurrent)
+	}
+	return t
+}
+
+func (t *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.semaphore != nil {
+		select {
+		case t.semaphore <- struct{}{}:
+		default:
+			log.Printf("[DEBUG] %s %s waits for one of %d concurrent request slots", req.Method, req.URL, cap(t.semaphore))
+			start := time.Now()
+			select {
+			case t.semaphore <- struct{}{}:
+				log.Printf("[DEBUG] %s %s got a request slot after %s", req.Method, req.URL, time.Since(start))
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		defer func() { <-t.semaphore }()
+	}
+
+	if t.limiter != nil {
+		if wait := t.limiter.reserve(); wait > 0 {
+			log.Printf("[DEBUG] %s %s throttled for %s by max_requests_per_second", req.Method, req.URL, wait)
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// tokenBucket refills at rate tokens per second and holds at most burst tokens.
+type tokenBucket struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    time.Duration
+	next     time.Time
+}
+
+func newTokenBucket(rate float64) *tokenBucket {
+	burst := int(rate)
+	if burst < 1 {
+		burst = 1
+	}
+	interval := time.Duration(float64(time.Second) / rate)
+	return &tokenBucket{
+		interval: interval,
+		burst:    time.Duration(burst-1) * interval,
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait before
+// the token is available.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if earliest := now.Add(-b.burst); b.next.Before(earliest) {
+		b.next = earliest
+	}
+	wait := b.next.Sub(now)
+	b.next = b.next.Add(b.interval)
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
@@ -0,0 +1,148 @@
+package awx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewThrottleTransport(t *testing.T) {
+	if transport := newThrottleTransport(http.DefaultTransport, 0, 0); transport != http.DefaultTransport {
+		t.Fatalf("expected the base transport without limits, got %T", transport)
+	}
+	transport := newThrottleTransport(http.DefaultTransport, 5, 0).(*throttleTransport)
+	if transport.limiter == nil || transport.semaphore != nil {
+		t.Fatalf("expected only a rate limit")
+	}
+	transport = newThrottleTransport(http.DefaultTransport, 0, 3).(*throttleTransport)
+	if transport.limiter != nil || cap(transport.semaphore) != 3 {
+		t.Fatalf("expected only a concurrency cap of 3")
+	}
+}
+
+func TestThrottleTransportConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}))
+	defer srv.Close()
+
+	transport := newThrottleTransport(http.DefaultTransport, 0, 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	if maxInFlight := atomic.LoadInt32(&maxInFlight); maxInFlight > 2 {
+		t.Fatalf("expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
+
+func TestThrottleTransportContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	cases := []struct {
+		name      string
+		transport http.RoundTripper
+		occupy    bool
+	}{
+		{name: "waiting for a slot", transport: newThrottleTransport(http.DefaultTransport, 0, 1), occupy: true},
+		{name: "waiting for a token", transport: newThrottleTransport(http.DefaultTransport, 0.1, 0)},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			if c.occupy {
+				// The first request holds the only slot until the server is released.
+				go func() {
+					req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+					if resp, err := c.transport.RoundTrip(req); err == nil {
+						resp.Body.Close()
+					}
+				}()
+				time.Sleep(20 * time.Millisecond)
+			} else {
+				// The first token is free, the next one comes in 10 seconds.
+				c.transport.(*throttleTransport).limiter.reserve()
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+			start := time.Now()
+			_, err := c.transport.RoundTrip(req)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("expected the context error, got %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Fatalf("the canceled request waited %s", elapsed)
+			}
+		})
+	}
+}
+
+func TestTokenBucketReserve(t *testing.T) {
+	cases := []struct {
+		name  string
+		rate  float64
+		waits []time.Duration
+	}{
+		{name: "burst of the rate", rate: 2, waits: []time.Duration{0, 0, 500 * time.Millisecond, time.Second}},
+		{name: "fractional rate", rate: 0.5, waits: []time.Duration{0, 2 * time.Second, 4 * time.Second}},
+		{name: "high rate", rate: 100, waits: []time.Duration{0, 0, 0}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			bucket := newTokenBucket(c.rate)
+			for i, expected := range c.waits {
+				wait := bucket.reserve()
+				// reserve runs a little later than the previous call.
+				if wait > expected || wait < expected-50*time.Millisecond {
+					t.Fatalf("reservation %d: expected a wait of about %s, got %s", i, expected, wait)
+				}
+			}
+		})
+	}
+}
+
+func TestTokenBucketRefill(t *testing.T) {
+	bucket := newTokenBucket(20)
+	for i := 0; i < 20; i++ {
+		bucket.reserve()
+	}
+	if wait := bucket.reserve(); wait <= 0 {
+		t.Fatalf("expected the drained bucket to make the caller wait")
+	}
+	time.Sleep(200 * time.Millisecond)
+	if wait := bucket.reserve(); wait != 0 {
+		t.Fatalf("expected the bucket to refill, got a wait of %s", wait)
+	}
+}
@@ -0,0 +1,266 @@
+package awx
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+	oidAES128CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidDESEDE3CBC     = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+)
+
+// encryptedPrivateKeyInfo is the PKCS#8 "ENCRYPTED PRIVATE KEY" structure, RFC 5208.
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// tlsMaterial holds the PEM encoded mTLS settings of the provider, read either
+// from the inline arguments or from the *_file arguments. Nothing is written to disk.
+type tlsMaterial struct {
+	clientCert    []byte
+	clientKey     []byte
+	keyPassphrase string
+	caCert        []byte
+}
+
+func readTLSMaterial(d *schema.ResourceData) (*tlsMaterial, error) {
+	material := &tlsMaterial{
+		keyPassphrase: d.Get("client_key_passphrase").(string),
+	}
+	var err error
+	if material.clientCert, err = readPEMArgument(d, "client_cert"); err != nil {
+		return nil, err
+	}
+	if material.clientKey, err = readPEMArgument(d, "client_key"); err != nil {
+		return nil, err
+	}
+	if material.caCert, err = readPEMArgument(d, "ca_cert"); err != nil {
+		return nil, err
+	}
+	return material, nil
+}
+
+// readPEMArgument returns the inline value of name, or the content of the
+// file referenced by name_file.
+func readPEMArgument(d *schema.ResourceData, name string) ([]byte, error) {
+	if value, ok := d.GetOk(name); ok {
+		return []byte(value.(string)), nil
+	}
+	fileArgument := name + "_file"
+	if path, ok := d.GetOk(fileArgument); ok {
+		content, err := os.ReadFile(path.(string))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s %s: %s", fileArgument, path, err)
+		}
+		return content, nil
+	}
+	return nil, nil
+}
+
+// applyTLSMaterial adds the client certificate and the CA of material to tlsConfig.
+// The CA can be used on its own, the client certificate requires its key.
+func applyTLSMaterial(tlsConfig *tls.Config, material *tlsMaterial) error {
+	if (material.clientCert == nil) != (material.clientKey == nil) {
+		return errors.New("client_cert and client_key must be provided together")
+	}
+
+	if material.clientCert != nil {
+		if err := checkPEMBlocks("client_cert", material.clientCert, "CERTIFICATE"); err != nil {
+			return err
+		}
+		keyPEM, err := decodeClientKey(material.clientKey, material.keyPassphrase)
+		if err != nil {
+			return err
+		}
+		cert, err := tls.X509KeyPair(material.clientCert, keyPEM)
+		if err != nil {
+			return fmt.Errorf("client_cert and client_key do not form a valid key pair: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if material.caCert != nil {
+		if err := checkPEMBlocks("ca_cert", material.caCert, "CERTIFICATE"); err != nil {
+			return err
+		}
+		caCertPool, _ := x509.SystemCertPool()
+		if caCertPool == nil {
+			caCertPool = x509.NewCertPool()
+		}
+		if !caCertPool.AppendCertsFromPEM(material.caCert) {
+			return errors.New("ca_cert does not contain a valid certificate")
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+	return nil
+}
+
+// checkPEMBlocks makes sure content holds at least one PEM block and only
+// blocks of the expected type.
+func checkPEMBlocks(name string, content []byte, blockType string) error {
+	rest := content
+	found := false
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != blockType {
+			return fmt.Errorf("%s contains a %q PEM block, expected %q", name, block.Type, blockType)
+		}
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("%s is not PEM encoded, no %q block found", name, blockType)
+	}
+	return nil
+}
+
+// decodeClientKey returns the client key as unencrypted PEM, decrypting
+// encrypted PKCS#8 keys with passphrase.
+func decodeClientKey(keyPEM []byte, passphrase string) ([]byte, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("client_key is not PEM encoded")
+	}
+
+	switch block.Type {
+	case "ENCRYPTED PRIVATE KEY":
+		if passphrase == "" {
+			return nil, errors.New("client_key is encrypted, client_key_passphrase is required")
+		}
+		der, err := decryptPKCS8PrivateKey(block.Bytes, []byte(passphrase))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decrypt client_key: %s", err)
+		}
+		if _, err := x509.ParsePKCS8PrivateKey(der); err != nil {
+			return nil, fmt.Errorf("unable to decrypt client_key, check client_key_passphrase: %s", err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+	case "PRIVATE KEY", "RSA PRIVATE KEY", "EC PRIVATE KEY":
+		if _, ok := block.Headers["DEK-Info"]; ok {
+			return nil, errors.New("client_key uses legacy PEM encryption, convert it to an encrypted PKCS#8 key")
+		}
+		return keyPEM, nil
+	default:
+		return nil, fmt.Errorf("client_key contains a %q PEM block, expected a private key", block.Type)
+	}
+}
+
+// decryptPKCS8PrivateKey decrypts a PBES2 encrypted PKCS#8 key, RFC 8018, and
+// returns the DER encoded PKCS#8 private key.
+func decryptPKCS8PrivateKey(der, passphrase []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("malformed encrypted PKCS#8 key: %s", err)
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("unsupported encryption %s, only PBES2 is supported", info.Algorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("malformed PBES2 parameters: %s", err)
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("unsupported key derivation %s, only PBKDF2 is supported", params.KeyDerivationFunc.Algorithm)
+	}
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, fmt.Errorf("malformed PBKDF2 parameters: %s", err)
+	}
+
+	var prf func() hash.Hash
+	switch {
+	case kdf.PRF.Algorithm == nil, kdf.PRF.Algorithm.Equal(oidHMACWithSHA1):
+		prf = sha1.New
+	case kdf.PRF.Algorithm.Equal(oidHMACWithSHA256):
+		prf = sha256.New
+	case kdf.PRF.Algorithm.Equal(oidHMACWithSHA384):
+		prf = sha512.New384
+	case kdf.PRF.Algorithm.Equal(oidHMACWithSHA512):
+		prf = sha512.New
+	default:
+		return nil, fmt.Errorf("unsupported PBKDF2 pseudo random function %s", kdf.PRF.Algorithm)
+	}
+
+	var keyLength int
+	var newCipher func([]byte) (cipher.Block, error)
+	switch scheme := params.EncryptionScheme.Algorithm; {
+	case scheme.Equal(oidAES128CBC):
+		keyLength, newCipher = 16, aes.NewCipher
+	case scheme.Equal(oidAES192CBC):
+		keyLength, newCipher = 24, aes.NewCipher
+	case scheme.Equal(oidAES256CBC):
+		keyLength, newCipher = 32, aes.NewCipher
+	case scheme.Equal(oidDESEDE3CBC):
+		keyLength, newCipher = 24, des.NewTripleDESCipher
+	default:
+		return nil, fmt.Errorf("unsupported cipher %s", scheme)
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("malformed cipher parameters: %s", err)
+	}
+
+	block, err := newCipher(pbkdf2.Key(passphrase, kdf.Salt, kdf.IterationCount, keyLength, prf))
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() || len(info.EncryptedData) == 0 || len(info.EncryptedData)%block.BlockSize() != 0 {
+		return nil, errors.New("malformed encrypted data")
+	}
+	plain := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, info.EncryptedData)
+
+	// PKCS#7 padding, a wrong passphrase almost always ends up here.
+	padding := int(plain[len(plain)-1])
+	if padding == 0 || padding > block.BlockSize() {
+		return nil, errors.New("wrong passphrase")
+	}
+	for _, b := range plain[len(plain)-padding:] {
+		if int(b) != padding {
+			return nil, errors.New("wrong passphrase")
+		}
+	}
+	return plain[:len(plain)-padding], nil
+}
@@ -0,0 +1,200 @@
+package awx
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The fixtures in testdata/tls are generated with
+//
+//	openssl ecparam -name prime256v1 -genkey -noout | openssl pkcs8 -topk8 -nocrypt -out client.key
+//	openssl req -x509 -new -key client.key -subj /CN=client -days 36500 -out client.crt
+//	openssl pkcs8 -topk8 -in client.key -v2 <cipher> -v2prf <prf> -iter 1000 -passout pass:secret -out client-<cipher>-<prf>.key
+//	openssl ec -in client.key -aes256 -passout pass:secret -out legacy.key
+//
+// other.key and ca.crt are a second key and a certificate of it.
+
+func readTLSFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", "tls", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func TestDecodeClientKeyEncrypted(t *testing.T) {
+	plain, _ := pem.Decode(readTLSFixture(t, "client.key"))
+	for _, cipher := range []string{"aes-128-cbc", "aes-192-cbc", "aes-256-cbc", "des3"} {
+		for _, prf := range []string{"hmacWithSHA1", "hmacWithSHA256", "hmacWithSHA384", "hmacWithSHA512"} {
+			name := "client-" + cipher + "-" + prf + ".key"
+			t.Run(name, func(t *testing.T) {
+				keyPEM := readTLSFixture(t, name)
+
+				decoded, err := decodeClientKey(keyPEM, "secret")
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				block, _ := pem.Decode(decoded)
+				if block == nil || block.Type != "PRIVATE KEY" || !bytes.Equal(block.Bytes, plain.Bytes) {
+					t.Fatalf("decrypted key differs from client.key")
+				}
+
+				_, err = decodeClientKey(keyPEM, "wrong")
+				if err == nil || !strings.Contains(err.Error(), "unable to decrypt client_key") {
+					t.Fatalf("expected a decryption error, got %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeClientKey(t *testing.T) {
+	encrypted := readTLSFixture(t, "client-aes-256-cbc-hmacWithSHA256.key")
+	block, _ := pem.Decode(encrypted)
+	truncatedDER := pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: block.Bytes[:len(block.Bytes)-20]})
+
+	cases := []struct {
+		name       string
+		key        []byte
+		passphrase string
+		err        string
+	}{
+		{name: "plain", key: readTLSFixture(t, "client.key")},
+		{name: "plain ignores passphrase", key: readTLSFixture(t, "client.key"), passphrase: "secret"},
+		{name: "missing passphrase", key: encrypted, err: "client_key_passphrase is required"},
+		{name: "legacy encryption", key: readTLSFixture(t, "legacy.key"), passphrase: "secret", err: "legacy PEM encryption"},
+		{name: "garbage", key: []byte("not a key"), err: "client_key is not PEM encoded"},
+		{name: "truncated PEM", key: encrypted[:len(encrypted)/2], passphrase: "secret", err: "client_key is not PEM encoded"},
+		{name: "truncated DER", key: truncatedDER, passphrase: "secret", err: "malformed"},
+		{name: "certificate", key: readTLSFixture(t, "client.crt"), err: `client_key contains a "CERTIFICATE" PEM block`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := decodeClientKey(c.key, c.passphrase)
+			if c.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Fatalf("expected error containing %q, got %v", c.err, err)
+			}
+		})
+	}
+}
+
+func TestApplyTLSMaterial(t *testing.T) {
+	cert := readTLSFixture(t, "client.crt")
+	key := readTLSFixture(t, "client.key")
+
+	cases := []struct {
+		name     string
+		material tlsMaterial
+		certs    int
+		rootCAs  bool
+		err      string
+	}{
+		{name: "empty"},
+		{name: "key pair", material: tlsMaterial{clientCert: cert, clientKey: key}, certs: 1},
+		{
+			name:     "encrypted key pair",
+			material: tlsMaterial{clientCert: cert, clientKey: readTLSFixture(t, "client-des3-hmacWithSHA512.key"), keyPassphrase: "secret"},
+			certs:    1,
+		},
+		{name: "CA only", material: tlsMaterial{caCert: readTLSFixture(t, "ca.crt")}, rootCAs: true},
+		{
+			name:     "key pair and CA",
+			material: tlsMaterial{clientCert: cert, clientKey: key, caCert: readTLSFixture(t, "ca.crt")},
+			certs:    1,
+			rootCAs:  true,
+		},
+		{name: "certificate without key", material: tlsMaterial{clientCert: cert}, err: "must be provided together"},
+		{name: "key without certificate", material: tlsMaterial{clientKey: key}, err: "must be provided together"},
+		{
+			name:     "mismatched key",
+			material: tlsMaterial{clientCert: cert, clientKey: readTLSFixture(t, "other.key")},
+			err:      "do not form a valid key pair",
+		},
+		{
+			name:     "key as certificate",
+			material: tlsMaterial{clientCert: key, clientKey: key},
+			err:      `client_cert contains a "PRIVATE KEY" PEM block, expected "CERTIFICATE"`,
+		},
+		{name: "garbage certificate", material: tlsMaterial{clientCert: []byte("garbage"), clientKey: key}, err: "client_cert is not PEM encoded"},
+		{name: "garbage CA", material: tlsMaterial{caCert: []byte("garbage")}, err: "ca_cert is not PEM encoded"},
+		{
+			name:     "invalid CA certificate",
+			material: tlsMaterial{caCert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("garbage")})},
+			err:      "ca_cert does not contain a valid certificate",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tlsConfig := &tls.Config{}
+			err := applyTLSMaterial(tlsConfig, &c.material)
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Fatalf("expected error containing %q, got %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(tlsConfig.Certificates) != c.certs {
+				t.Fatalf("expected %d client certificates, got %d", c.certs, len(tlsConfig.Certificates))
+			}
+			if (tlsConfig.RootCAs != nil) != c.rootCAs {
+				t.Fatalf("expected RootCAs set %t, got %t", c.rootCAs, tlsConfig.RootCAs != nil)
+			}
+		})
+	}
+}
+
+func TestReadPEMArgument(t *testing.T) {
+	path := filepath.Join("testdata", "tls", "ca.crt")
+	content := readTLSFixture(t, "ca.crt")
+
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		value  []byte
+		err    string
+	}{
+		{name: "unset", config: map[string]interface{}{}},
+		{name: "inline", config: map[string]interface{}{"ca_cert": string(content)}, value: content},
+		{name: "file", config: map[string]interface{}{"ca_cert_file": path}, value: content},
+		{
+			name:   "missing file",
+			config: map[string]interface{}{"ca_cert_file": filepath.Join("testdata", "tls", "missing.crt")},
+			err:    "unable to read ca_cert_file",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, Provider().Schema, c.config)
+			value, err := readPEMArgument(d, "ca_cert")
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Fatalf("expected error containing %q, got %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !bytes.Equal(value, c.value) {
+				t.Fatalf("expected %q, got %q", c.value, value)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package awx
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// newHTTPClient builds a dedicated http.Client for a provider instance, so TLS
+// and proxy settings never leak into http.DefaultClient or into other aliased
+// provider blocks.
+func newHTTPClient(d *schema.ResourceData) (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: d.Get("insecure").(bool),
+	}
+
+	material, err := readTLSMaterial(d)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyTLSMaterial(tlsConfig, material); err != nil {
+		return nil, fmt.Errorf("invalid mTLS config: %s", err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	if maxIdleConns := d.Get("max_idle_conns").(int); maxIdleConns > 0 {
+		// All connections go to the AWX host, the per host limit would
+		// otherwise stay at the default of 2.
+		transport.MaxIdleConns = maxIdleConns
+		transport.MaxIdleConnsPerHost = maxIdleConns
+	}
+
+	if proxyURL := d.Get("proxy_url").(string); proxyURL != "" {
+		proxy, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy_url %s: %s", proxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if !d.Get("enable_http2").(bool) {
+		// A non-nil, empty TLSNextProto disables the HTTP/2 upgrade.
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	retryWaitMin := time.Duration(d.Get("retry_wait_min").(int)) * time.Second
+	retryWaitMax := time.Duration(d.Get("retry_wait_max").(int)) * time.Second
+	if retryWaitMax < retryWaitMin {
+		return nil, fmt.Errorf("retry_wait_max (%s) must not be lower than retry_wait_min (%s)", retryWaitMax, retryWaitMin)
+	}
+
+	throttled := newThrottleTransport(
+		transport,
+		d.Get("max_requests_per_second").(float64),
+		d.Get("max_concurrent_requests").(int),
+	)
+
+	return &http.Client{
+		Transport: &retryTransport{
+			base:       throttled,
+			maxRetries: d.Get("max_retries").(int),
+			waitMin:    retryWaitMin,
+			waitMax:    retryWaitMax,
+			timeout:    time.Duration(d.Get("request_timeout").(int)) * time.Second,
+		},
+	}, nil
+}
@@ -0,0 +1,305 @@
+/*
+Runs a single Ansible module against an inventory and waits until the ad hoc
+command is finished. The command runs again when one of the arguments or the
+triggers change, destroying the resource keeps the ad hoc command in AWX.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_ad_hoc_command" "ping" {
+	  inventory_id  = awx_inventory.default.id
+	  credential_id = awx_credential_machine.pi_connection.id
+	  module_name   = "ping"
+	  limit         = awx_host.web.name
+
+	  triggers = {
+	    host_id = awx_host.web.id
+	  }
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const adHocCommandsAPIEndpoint = "/api/v2/ad_hoc_commands/"
+
+// adHocCommandHostStatuses maps the runner events of a host to its result.
+var adHocCommandHostStatuses = map[string]string{
+	"runner_on_ok":          "ok",
+	"runner_on_failed":      "failed",
+	"runner_on_unreachable": "unreachable",
+	"runner_on_skipped":     "skipped",
+}
+
+type adHocCommandEvent struct {
+	Event     string `json:"event"`
+	HostName  string `json:"host_name"`
+	Changed   bool   `json:"changed"`
+	EventData struct {
+		Res map[string]interface{} `json:"res"`
+	} `json:"event_data"`
+}
+
+func resourceAdHocCommand() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAdHocCommandCreate,
+		ReadContext:   resourceAdHocCommandRead,
+		UpdateContext: resourceAdHocCommandUpdate,
+		DeleteContext: resourceAdHocCommandDelete,
+
+		Schema: unifiedJobSchema("ad hoc command", map[string]*schema.Schema{
+			"inventory_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Numeric ID of the inventory to run the module against",
+			},
+			"credential_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Numeric ID of the machine credential used to connect to the hosts",
+			},
+			"module_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Ansible module to run, it has to be allowed by the AWX ad hoc commands setting",
+			},
+			"module_args": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+				Description: "Arguments of the module",
+			},
+			"limit": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+				Description: "Host pattern limiting the hosts of the inventory",
+			},
+			"forks": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of parallel processes, 0 uses the Ansible default",
+			},
+			"verbosity": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 5),
+				Description:  "One of 0,1,2,3,4,5",
+			},
+			"become_enabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Run the module with privilege escalation",
+			},
+			"extra_vars": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+				StateFunc:   normalizeJsonYaml,
+				Description: "Extra variables in JSON or YAML",
+			},
+			"triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values, the ad hoc command runs again when it changes",
+			},
+			"host_results": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Result of the module on every host",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the host",
+						},
+						"status": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "One of ok, failed, unreachable, skipped",
+						},
+						"changed": &schema.Schema{
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the module changed the host",
+						},
+						"rc": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Return code of command modules",
+						},
+						"stdout": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Standard output of command modules",
+						},
+						"msg": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Message of the module",
+						},
+					},
+				},
+			},
+		}),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+	}
+}
+
+func resourceAdHocCommandCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	inventoryID := d.Get("inventory_id").(int)
+
+	data := map[string]interface{}{
+		"job_type":       "run",
+		"inventory":      inventoryID,
+		"module_name":    d.Get("module_name").(string),
+		"module_args":    d.Get("module_args").(string),
+		"limit":          d.Get("limit").(string),
+		"forks":          d.Get("forks").(int),
+		"verbosity":      d.Get("verbosity").(int),
+		"become_enabled": d.Get("become_enabled").(bool),
+		"extra_vars":     d.Get("extra_vars").(string),
+	}
+	if v, ok := d.GetOk("credential_id"); ok {
+		data["credential"] = v.(int)
+	}
+
+	result := new(unifiedJob)
+	if err := apiPost(m, adHocCommandsAPIEndpoint, data, result); err != nil {
+		log.Printf("Fail to create AdHocCommand %v", err)
+		return buildDiagAPIFail(
+			"Unable to create AdHocCommand", err, nil,
+			"AdHocCommand %s in the inventory id %d faild to create %s", d.Get("module_name").(string), inventoryID, err.Error(),
+		)
+	}
+	d.SetId(strconv.Itoa(result.ID))
+
+	return waitForLaunchedJob(ctx, d, m, launchedJob{
+		kind:     "AdHocCommand",
+		endpoint: adHocCommandsAPIEndpoint,
+		id:       result.ID,
+	}, resourceAdHocCommandRead)
+}
+
+func resourceAdHocCommandUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only fail_on_job_failure can change without running the command again.
+	return resourceAdHocCommandRead(ctx, d, m)
+}
+
+func resourceAdHocCommandRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read AdHocCommand", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	res, diags := getLaunchedJob(m, "ad hoc command", adHocCommandsAPIEndpoint, id)
+	if res == nil {
+		return diags
+	}
+	events, err := listAdHocCommandHostEvents(m, id)
+	if err != nil {
+		return buildDiagNotFoundFail("ad hoc command events", id, err)
+	}
+	setAdHocCommandResourceData(d, res, events)
+	return nil
+}
+
+func resourceAdHocCommandDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Ad hoc commands are part of the AWX history, only the state is cleared.
+	d.SetId("")
+	return nil
+}
+
+// listAdHocCommandHostEvents returns the events holding the result of a host.
+func listAdHocCommandHostEvents(m interface{}, id int) ([]adHocCommandEvent, error) {
+	var events []adHocCommandEvent
+	query := map[string]string{
+		"event__in": "runner_on_ok,runner_on_failed,runner_on_unreachable,runner_on_skipped",
+		"order_by":  "counter",
+	}
+	err := apiGetAll(m, fmt.Sprintf("%s%d/events/", adHocCommandsAPIEndpoint, id), query, func(results json.RawMessage) error {
+		var page []adHocCommandEvent
+		if err := json.Unmarshal(results, &page); err != nil {
+			return err
+		}
+		events = append(events, page...)
+		return nil
+	})
+	return events, err
+}
+
+func setAdHocCommandResourceData(d *schema.ResourceData, r *unifiedJob, events []adHocCommandEvent) *schema.ResourceData {
+	d.Set("status", r.Status)
+	d.Set("failed", r.Failed)
+	d.Set("started", formatJobTime(r.Started))
+	d.Set("finished", formatJobTime(r.Finished))
+	d.Set("elapsed", r.Elapsed)
+
+	hostResults := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		status, ok := adHocCommandHostStatuses[event.Event]
+		if !ok {
+			continue
+		}
+		res := event.EventData.Res
+		rc, _ := res["rc"].(float64)
+		stdout, _ := res["stdout"].(string)
+		hostResults = append(hostResults, map[string]interface{}{
+			"host":    event.HostName,
+			"status":  status,
+			"changed": event.Changed,
+			"rc":      int(rc),
+			"stdout":  stdout,
+			"msg":     flattenEventMessage(res["msg"]),
+		})
+	}
+	d.Set("host_results", hostResults)
+	return d
+}
+
+// flattenEventMessage returns the msg of a module result, modules don't
+// always return it as string.
+func flattenEventMessage(msg interface{}) string {
+	switch v := msg.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
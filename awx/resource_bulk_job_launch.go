@@ -0,0 +1,349 @@
+/*
+Launches several job templates with one request to the bulk API of AWX and
+waits until the workflow job AWX runs them in is finished. The jobs are
+launched again when one of the arguments or the triggers change, destroying
+the resource keeps the jobs in AWX. The bulk API requires AWX 22 or later.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_bulk_job_launch" "rollout" {
+	  name                = "rollout ${var.version}"
+	  fail_on_job_failure = true
+
+	  dynamic "job" {
+	    for_each = awx_inventory.region
+	    content {
+	      unified_job_template_id = awx_job_template.deploy.id
+	      inventory_id            = job.value.id
+	      extra_vars              = jsonencode({ "version" = var.version })
+	    }
+	  }
+
+	  triggers = {
+	    version = var.version
+	  }
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+const bulkJobLaunchAPIEndpoint = "/api/v2/bulk/job_launch/"
+
+var bulkJobLaunchFieldAttributes = map[string]string{
+	"jobs":         "job",
+	"organization": "organization_id",
+}
+
+func resourceBulkJobLaunch() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBulkJobLaunchCreate,
+		ReadContext:   resourceBulkJobLaunchRead,
+		UpdateContext: resourceBulkJobLaunchUpdate,
+		DeleteContext: resourceBulkJobLaunchDelete,
+
+		Schema: unifiedJobSchema("workflow job", map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Name of the workflow job running the jobs",
+			},
+			"organization_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Numeric ID of the organization of the workflow job, required for users who aren't superusers",
+			},
+			"job": &schema.Schema{
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "Jobs to launch, in the order of the jobs attribute",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"unified_job_template_id": &schema.Schema{
+							Type:        schema.TypeInt,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Numeric ID of the job template, project or inventory source to launch",
+						},
+						"inventory_id": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Inventory applied as a prompt, assuming the template prompts for inventory",
+						},
+						"credential_ids": &schema.Schema{
+							Type:        schema.TypeList,
+							Optional:    true,
+							ForceNew:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "Credentials applied as a prompt, assuming the template prompts for credentials",
+						},
+						"extra_vars": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							StateFunc:   normalizeJsonYaml,
+							Description: "Extra variables in JSON or YAML, the template has to prompt for variables or define a survey",
+						},
+						"limit": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Host pattern applied as a prompt, assuming the template prompts for limit",
+						},
+						"job_tags": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Tags applied as a prompt, assuming the template prompts for tags",
+						},
+						"skip_tags": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Skipped tags applied as a prompt, assuming the template prompts for skipped tags",
+						},
+						"scm_branch": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Branch applied as a prompt, assuming the template prompts for the SCM branch",
+						},
+						"verbosity": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntBetween(0, 5),
+							Description:  "One of 0,1,2,3,4,5 applied as a prompt, 0 keeps the verbosity of the template",
+						},
+						"diff_mode": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Enables diff mode as a prompt, false keeps the diff mode of the template",
+						},
+					},
+				},
+			},
+			"triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values, the jobs are launched again when it changes",
+			},
+			"fail_on_job_failure": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Fail the apply when one of the jobs doesn't succeed",
+			},
+			"workflow_job_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Numeric ID of the workflow job running the jobs",
+			},
+			"jobs": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Results of the launched jobs",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"unified_job_template_id": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Numeric ID of the launched template",
+						},
+						"job_id": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Numeric ID of the job, 0 while it isn't started",
+						},
+						"status": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the job",
+						},
+					},
+				},
+			},
+		}),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+	}
+}
+
+func resourceBulkJobLaunchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	jobs, err := expandBulkJobs(d.Get("job").([]interface{}))
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"Unable to launch bulk jobs",
+			"Invalid job: %s", err.Error(),
+		)
+	}
+	data := map[string]interface{}{
+		"jobs": jobs,
+	}
+	if v, ok := d.GetOk("name"); ok {
+		data["name"] = v.(string)
+	}
+	if v, ok := d.GetOk("organization_id"); ok {
+		data["organization"] = v.(int)
+	}
+
+	result := new(unifiedJob)
+	err = apiPost(m, bulkJobLaunchAPIEndpoint, data, result)
+	if err == nil && result.ID == 0 {
+		err = fmt.Errorf("invalid workflow job id 0")
+	}
+	if err != nil {
+		log.Printf("Fail to launch bulk jobs %v", err)
+		if isNotFound(err) {
+			return buildDiagnosticsMessage(
+				"Unable to launch bulk jobs",
+				"AWX has no bulk API at %s, awx_bulk_job_launch requires AWX 22 or later", bulkJobLaunchAPIEndpoint,
+			)
+		}
+		return buildDiagAPIFail(
+			"Unable to launch bulk jobs", err, bulkJobLaunchFieldAttributes,
+			"%d bulk jobs faild to launch %s", len(jobs), err.Error(),
+		)
+	}
+	d.SetId(strconv.Itoa(result.ID))
+
+	return waitForLaunchedJob(ctx, d, m, launchedJob{
+		kind:     "WorkflowJob",
+		endpoint: workflowJobsAPIEndpoint,
+		id:       result.ID,
+		origin:   "of the bulk jobs",
+		failures: describeFailedBulkJobs,
+	}, resourceBulkJobLaunchRead)
+}
+
+func resourceBulkJobLaunchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only fail_on_job_failure can change without launching the jobs again.
+	return resourceBulkJobLaunchRead(ctx, d, m)
+}
+
+func resourceBulkJobLaunchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read bulk jobs", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	res, diags := getLaunchedJob(m, "workflow job", workflowJobsAPIEndpoint, id)
+	if res == nil {
+		return diags
+	}
+	nodes, err := listWorkflowJobNodes(m, id)
+	if err != nil {
+		return buildDiagNotFoundFail("workflow job nodes", id, err)
+	}
+	setBulkJobLaunchResourceData(d, res, nodes)
+	return nil
+}
+
+func resourceBulkJobLaunchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Jobs are part of the AWX history, only the state is cleared.
+	d.SetId("")
+	return nil
+}
+
+// expandBulkJobs builds the jobs of a bulk launch, only the configured prompts
+// are sent.
+func expandBulkJobs(items []interface{}) ([]map[string]interface{}, error) {
+	jobs := make([]map[string]interface{}, 0, len(items))
+	for i, item := range items {
+		job := item.(map[string]interface{})
+		data := map[string]interface{}{
+			"unified_job_template": job["unified_job_template_id"].(int),
+		}
+		if v := job["inventory_id"].(int); v != 0 {
+			data["inventory"] = v
+		}
+		if v := job["credential_ids"].([]interface{}); len(v) > 0 {
+			data["credentials"] = v
+		}
+		if v := job["extra_vars"].(string); v != "" {
+			extraData, err := expandExtraVars(v)
+			if err != nil {
+				return nil, fmt.Errorf("job %d: %s", i, err)
+			}
+			data["extra_data"] = extraData
+		}
+		for _, key := range []string{"limit", "job_tags", "skip_tags", "scm_branch"} {
+			if v := job[key].(string); v != "" {
+				data[key] = v
+			}
+		}
+		if v := job["verbosity"].(int); v != 0 {
+			data["verbosity"] = v
+		}
+		if v := job["diff_mode"].(bool); v {
+			data["diff_mode"] = v
+		}
+		jobs = append(jobs, data)
+	}
+	return jobs, nil
+}
+
+func setBulkJobLaunchResourceData(d *schema.ResourceData, r *unifiedJob, nodes []workflowJobNode) *schema.ResourceData {
+	d.Set("workflow_job_id", r.ID)
+	d.Set("status", r.Status)
+	d.Set("failed", r.Failed)
+	d.Set("started", formatJobTime(r.Started))
+	d.Set("finished", formatJobTime(r.Finished))
+	d.Set("elapsed", r.Elapsed)
+
+	jobs := make([]interface{}, 0, len(nodes))
+	for _, node := range nodes {
+		jobs = append(jobs, map[string]interface{}{
+			"unified_job_template_id": node.UnifiedJobTemplate,
+			"job_id":                  node.Job,
+			"status":                  node.status(),
+		})
+	}
+	d.Set("jobs", jobs)
+	return d
+}
+
+// describeFailedBulkJobs lists the jobs of the state that didn't succeed, for
+// the failure diagnostic. The workflow job of a bulk launch succeeds even when
+// its jobs fail.
+func describeFailedBulkJobs(d *schema.ResourceData) string {
+	failed := ""
+	for i, item := range d.Get("jobs").([]interface{}) {
+		job := item.(map[string]interface{})
+		switch status := job["status"].(string); status {
+		case awx.JobStatusFailed, awx.JobStatusError, awx.JobStatusCanceled:
+			if failed == "" {
+				failed = ", failed jobs:"
+			} else {
+				failed += ","
+			}
+			failed += fmt.Sprintf(" %d (template %d, job %d, %s)", i, job["unified_job_template_id"], job["job_id"], status)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,266 @@
+/*
+Manages a credential of any credential type, including custom ones. The type
+is given by its id or looked up by kind, namespace and name, as the ids of the
+types AWX ships differ between installs. AWX never returns secret inputs, they
+are kept as configured and changes made to them outside of terraform aren't
+detected.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential" "vault" {
+	  name            = "Vault"
+	  organisation_id = awx_organization.default.id
+
+	  credential_type {
+	    namespace = "hashivault_kv"
+	  }
+
+	  inputs = {
+	    url         = "https://vault.example.com"
+	    token       = var.vault_token
+	    api_version = "v2"
+	  }
+	}
+
+	resource "awx_credential" "custom" {
+	  name               = "Service token"
+	  organisation_id    = awx_organization.default.id
+	  credential_type_id = var.service_credential_type_id
+
+	  inputs = {
+	    token = var.service_token
+	  }
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// credentialTypeSelectors are the arguments the credential type is given by.
+var credentialTypeSelectors = []string{"credential_type_id", "credential_type"}
+
+var genericCredentialFieldAttributes = map[string]string{
+	"credential_type": "credential_type_id",
+}
+
+type credential struct {
+	ID             int                    `json:"id"`
+	Name           string                 `json:"name"`
+	Description    string                 `json:"description"`
+	Organization   int                    `json:"organization"`
+	CredentialType int                    `json:"credential_type"`
+	Kind           string                 `json:"kind"`
+	Inputs         map[string]interface{} `json:"inputs"`
+}
+
+func resourceCredential() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCredentialCreate,
+		ReadContext:   resourceCredentialRead,
+		UpdateContext: resourceCredentialUpdate,
+		DeleteContext: CredentialsServiceDeleteByID,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"organisation_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Numeric ID of the organization, credentials without one are private to their user",
+			},
+			"credential_type_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: credentialTypeSelectors,
+				Description:  "Numeric ID of the credential type",
+			},
+			"credential_type": &schema.Schema{
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				ExactlyOneOf: credentialTypeSelectors,
+				Description:  "Looks up the credential type, exactly one type has to match all given values",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"kind": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Kind of the credential type, like ssh, scm, cloud, net, vault or external",
+						},
+						"namespace": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Namespace of a credential type AWX ships, like aws or hashivault_kv",
+						},
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Name of the credential type",
+						},
+					},
+				},
+			},
+			"inputs": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Inputs of the credential by field id, booleans as true or false",
+			},
+			"kind": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Kind of the credential type",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceCredentialCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	t, diags := resolveCredentialType(d, m)
+	if diags.HasError() {
+		return diags
+	}
+	data, diags := expandCredential(d, t)
+	if diags.HasError() {
+		return diags
+	}
+	data["credential_type"] = t.ID
+
+	result := new(credential)
+	if err := apiPost(m, credentialsAPIEndpoint, data, result); err != nil {
+		log.Printf("Fail to create Credential %v", err)
+		return buildDiagAPIFail(
+			"Unable to create new credentials", err, genericCredentialFieldAttributes,
+			"Unable to create new credentials: %s", err.Error(),
+		)
+	}
+	d.SetId(strconv.Itoa(result.ID))
+	return resourceCredentialRead(ctx, d, m)
+}
+
+func resourceCredentialUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Update Credential", d)
+	if diags.HasError() {
+		return diags
+	}
+	t, err := getCredentialType(m, d.Get("credential_type_id").(int))
+	if err != nil {
+		return buildDiagNotFoundFail("credential type", d.Get("credential_type_id").(int), err)
+	}
+	data, diags := expandCredential(d, t)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := apiPatch(m, fmt.Sprintf("%s%d/", credentialsAPIEndpoint, id), data, nil); err != nil {
+		return buildDiagAPIFail(
+			"Unable to update existing credentials", err, genericCredentialFieldAttributes,
+			"Unable to update existing credentials with id %d: %s", id, err.Error(),
+		)
+	}
+	return resourceCredentialRead(ctx, d, m)
+}
+
+func resourceCredentialRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read Credential", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	res := new(credential)
+	if err := apiGet(m, fmt.Sprintf("%s%d/", credentialsAPIEndpoint, id), res, nil); err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "credentials", id)
+		}
+		return buildDiagNotFoundFail("credentials", id, err)
+	}
+	d.Set("name", res.Name)
+	d.Set("description", res.Description)
+	d.Set("organisation_id", res.Organization)
+	d.Set("credential_type_id", res.CredentialType)
+	d.Set("kind", res.Kind)
+	d.Set("inputs", flattenCredentialInputs(res.Inputs, d.Get("inputs").(map[string]interface{})))
+	return diags
+}
+
+// resolveCredentialType returns the credential_type_id type, or the one the
+// credential_type block looks up.
+func resolveCredentialType(d *schema.ResourceData, m interface{}) (*credentialType, diag.Diagnostics) {
+	if id, ok := d.GetOk("credential_type_id"); ok {
+		t, err := getCredentialType(m, id.(int))
+		if err != nil {
+			return nil, buildDiagNotFoundFail("credential type", id.(int), err)
+		}
+		return t, nil
+	}
+
+	params := map[string]string{}
+	if lookups := d.Get("credential_type").([]interface{}); len(lookups) > 0 && lookups[0] != nil {
+		for key, value := range lookups[0].(map[string]interface{}) {
+			params[key] = value.(string)
+		}
+	}
+	if params["kind"] == "" && params["namespace"] == "" && params["name"] == "" {
+		return nil, buildDiagnosticsMessage(
+			"Invalid credential_type",
+			"credential_type of Credential %s needs one of kind, namespace or name", d.Get("name").(string),
+		)
+	}
+	t, err := findCredentialType(m, params)
+	if err != nil {
+		return nil, buildDiagnosticsMessage(
+			"Unable to find CredentialType",
+			"Fail to find the credential type of Credential %s: %s", d.Get("name").(string), err.Error(),
+		)
+	}
+	return t, nil
+}
+
+func expandCredential(d *schema.ResourceData, t *credentialType) (map[string]interface{}, diag.Diagnostics) {
+	inputs, err := expandCredentialInputs(t, d.Get("inputs").(map[string]interface{}))
+	if err != nil {
+		return nil, buildDiagnosticsMessage(
+			"Invalid inputs",
+			"Credential %s has invalid inputs: %s", d.Get("name").(string), err.Error(),
+		)
+	}
+	data := map[string]interface{}{
+		"name":         d.Get("name").(string),
+		"description":  d.Get("description").(string),
+		"organization": nil,
+		"inputs":       inputs,
+	}
+	if v := d.Get("organisation_id").(int); v != 0 {
+		data["organization"] = v
+	}
+	return data, nil
+}
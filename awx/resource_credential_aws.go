@@ -0,0 +1,206 @@
+/*
+Manages an Amazon Web Services credential for inventory sources and jobs,
+with an optional STS token. AWX versions whose AWS credential type declares
+role_arn and external_id can assume a role with it.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_aws" "production" {
+	  name            = "AWS production"
+	  organisation_id = awx_organization.default.id
+	  access_key      = var.aws_access_key
+	  secret_key      = var.aws_secret_key
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+func resourceCredentialAWS() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCredentialAWSCreate,
+		ReadContext:   resourceCredentialAWSRead,
+		UpdateContext: resourceCredentialAWSUpdate,
+		DeleteContext: CredentialsServiceDeleteByID,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"organisation_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"access_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Access key ID",
+			},
+			"secret_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Secret access key",
+			},
+			"security_token": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "STS token of temporary security credentials",
+			},
+			"role_arn": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ARN of the role to assume, the AWS credential type of the AWX has to declare it",
+			},
+			"external_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"role_arn"},
+				Description:  "External ID of the role to assume",
+			},
+		},
+	}
+}
+
+// expandCredentialAWSInputs returns the inputs of the credential, the assume
+// role inputs only when set as not every AWX declares them.
+func expandCredentialAWSInputs(d *schema.ResourceData) map[string]interface{} {
+	inputs := map[string]interface{}{
+		"username":       d.Get("access_key").(string),
+		"password":       d.Get("secret_key").(string),
+		"security_token": d.Get("security_token").(string),
+	}
+	for _, key := range []string{"role_arn", "external_id"} {
+		if v := d.Get(key).(string); v != "" {
+			inputs[key] = v
+		}
+	}
+	return inputs
+}
+
+func resourceCredentialAWSCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	awsType, diags := managedCredentialType(m, "aws")
+	if diags.HasError() {
+		return diags
+	}
+	inputs := expandCredentialAWSInputs(d)
+	if err := checkCredentialInputs(awsType, inputs); err != nil {
+		return buildDiagnosticsMessage("Unable to create new credentials", "Unable to create new credentials: %s", err.Error())
+	}
+	newCredential := map[string]interface{}{
+		"name":            d.Get("name").(string),
+		"description":     d.Get("description").(string),
+		"organization":    d.Get("organisation_id").(int),
+		"credential_type": awsType.ID,
+		"inputs":          inputs,
+	}
+
+	client := m.(*awx.AWX)
+	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
+	if err != nil {
+		return buildDiagAPIFail(
+			"Unable to create new credentials", err, credentialFieldAttributes,
+			"Unable to create new credentials: %s", err.Error(),
+		)
+	}
+
+	d.SetId(strconv.Itoa(cred.ID))
+	resourceCredentialAWSRead(ctx, d, m)
+
+	return diags
+}
+
+func resourceCredentialAWSRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*awx.AWX)
+	id, _ := strconv.Atoi(d.Id())
+	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
+	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "credentials", id)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to fetch credentials",
+			Detail:   fmt.Sprintf("Unable to credentials with id %d: %s", id, err.Error()),
+		})
+		return diags
+	}
+
+	d.Set("name", cred.Name)
+	d.Set("description", cred.Description)
+	d.Set("access_key", cred.Inputs["username"])
+	setCredentialSecret(d, "secret_key", cred.Inputs["password"])
+	setCredentialSecret(d, "security_token", cred.Inputs["security_token"])
+	d.Set("role_arn", cred.Inputs["role_arn"])
+	d.Set("external_id", cred.Inputs["external_id"])
+	d.Set("organisation_id", cred.OrganizationID)
+
+	return diags
+}
+
+func resourceCredentialAWSUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	keys := []string{
+		"name",
+		"description",
+		"access_key",
+		"secret_key",
+		"security_token",
+		"role_arn",
+		"external_id",
+		"organisation_id",
+	}
+
+	if d.HasChanges(keys...) {
+		var err error
+
+		id, _ := strconv.Atoi(d.Id())
+		awsType, diags := managedCredentialType(m, "aws")
+		if diags.HasError() {
+			return diags
+		}
+		inputs := expandCredentialAWSInputs(d)
+		if err := checkCredentialInputs(awsType, inputs); err != nil {
+			return buildDiagnosticsMessage("Unable to update existing credentials", "Unable to update existing credentials with id %d: %s", id, err.Error())
+		}
+		updatedCredential := map[string]interface{}{
+			"name":            d.Get("name").(string),
+			"description":     d.Get("description").(string),
+			"organization":    d.Get("organisation_id").(int),
+			"credential_type": awsType.ID,
+			"inputs":          inputs,
+		}
+
+		client := m.(*awx.AWX)
+		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
+		if err != nil {
+			return buildDiagAPIFail(
+				"Unable to update existing credentials", err, credentialFieldAttributes,
+				"Unable to update existing credentials with id %d: %s", id, err.Error(),
+			)
+		}
+	}
+
+	return resourceCredentialAWSRead(ctx, d, m)
+}
@@ -64,11 +64,15 @@ func resourceCredentialAzureKeyVaultCreate(ctx context.Context, d *schema.Resour
 	var diags diag.Diagnostics
 	var err error
 
+	credentialTypeID, diags := managedCredentialTypeID(m, "azure_kv")
+	if diags.HasError() {
+		return diags
+	}
 	newCredential := map[string]interface{}{
 		"name":            d.Get("name").(string),
 		"description":     d.Get("description").(string),
 		"organization":    d.Get("organisation_id").(int),
-		"credential_type": 19, // Azure Key Vault
+		"credential_type": credentialTypeID,
 		"inputs": map[string]interface{}{
 			"url":    d.Get("url").(string),
 			"client": d.Get("client").(string),
@@ -80,12 +84,10 @@ func resourceCredentialAzureKeyVaultCreate(ctx context.Context, d *schema.Resour
 	client := m.(*awx.AWX)
 	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credentials",
-			Detail:   fmt.Sprintf("Unable to create new credentials: %s", err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail(
+			"Unable to create new credentials", err, credentialFieldAttributes,
+			"Unable to create new credentials: %s", err.Error(),
+		)
 	}
 
 	d.SetId(strconv.Itoa(cred.ID))
@@ -101,6 +103,9 @@ func resourceCredentialAzureKeyVaultRead(ctx context.Context, d *schema.Resource
 	id, _ := strconv.Atoi(d.Id())
 	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
 	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "credentials", id)
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to fetch credentials",
@@ -121,8 +126,6 @@ func resourceCredentialAzureKeyVaultRead(ctx context.Context, d *schema.Resource
 }
 
 func resourceCredentialAzureKeyVaultUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-
 	keys := []string{
 		"name",
 		"description",
@@ -136,11 +139,15 @@ func resourceCredentialAzureKeyVaultUpdate(ctx context.Context, d *schema.Resour
 		var err error
 
 		id, _ := strconv.Atoi(d.Id())
+		credentialTypeID, diags := managedCredentialTypeID(m, "azure_kv")
+		if diags.HasError() {
+			return diags
+		}
 		updatedCredential := map[string]interface{}{
 			"name":            d.Get("name").(string),
 			"description":     d.Get("description").(string),
 			"organization":    d.Get("organisation_id").(int),
-			"credential_type": 19, // Azure Key Vault
+			"credential_type": credentialTypeID,
 			"inputs": map[string]interface{}{
 				"url":    d.Get("url").(string),
 				"client": d.Get("client").(string),
@@ -152,12 +159,10 @@ func resourceCredentialAzureKeyVaultUpdate(ctx context.Context, d *schema.Resour
 		client := m.(*awx.AWX)
 		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credentials",
-				Detail:   fmt.Sprintf("Unable to update existing credentials with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return buildDiagAPIFail(
+				"Unable to update existing credentials", err, credentialFieldAttributes,
+				"Unable to update existing credentials with id %d: %s", id, err.Error(),
+			)
 		}
 	}
 
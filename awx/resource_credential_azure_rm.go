@@ -0,0 +1,228 @@
+/*
+Manages an Azure Resource Manager credential for inventory sources and jobs.
+It authenticates either as a service principal with client_id, client_secret
+and tenant_id, or as a user with username and password.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_azure_rm" "production" {
+	  name            = "Azure production"
+	  organisation_id = awx_organization.default.id
+	  subscription_id = var.azure_subscription_id
+	  client_id       = var.azure_client_id
+	  client_secret   = var.azure_client_secret
+	  tenant_id       = var.azure_tenant_id
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+// credentialAzureRMModes are the arguments selecting how the credential
+// authenticates.
+var credentialAzureRMModes = []string{"client_id", "username"}
+
+func resourceCredentialAzureRM() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCredentialAzureRMCreate,
+		ReadContext:   resourceCredentialAzureRMRead,
+		UpdateContext: resourceCredentialAzureRMUpdate,
+		DeleteContext: CredentialsServiceDeleteByID,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"organisation_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"subscription_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the Azure subscription",
+			},
+			"client_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: credentialAzureRMModes,
+				RequiredWith: []string{"client_id", "client_secret", "tenant_id"},
+				Description:  "Client ID of the service principal",
+			},
+			"client_secret": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"client_id", "client_secret", "tenant_id"},
+				Description:  "Client secret of the service principal",
+			},
+			"tenant_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_id", "client_secret", "tenant_id"},
+				Description:  "Tenant ID of the service principal",
+			},
+			"username": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: credentialAzureRMModes,
+				RequiredWith: []string{"username", "password"},
+				Description:  "Username of an Azure AD user",
+			},
+			"password": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"username", "password"},
+				Description:  "Password of the Azure AD user",
+			},
+			"cloud_environment": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Azure cloud of the subscription, like AzureCloud, AzureChinaCloud or AzureUSGovernment",
+			},
+		},
+	}
+}
+
+func expandCredentialAzureRMInputs(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"subscription":      d.Get("subscription_id").(string),
+		"client":            d.Get("client_id").(string),
+		"secret":            d.Get("client_secret").(string),
+		"tenant":            d.Get("tenant_id").(string),
+		"username":          d.Get("username").(string),
+		"password":          d.Get("password").(string),
+		"cloud_environment": d.Get("cloud_environment").(string),
+	}
+}
+
+func resourceCredentialAzureRMCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	azureType, diags := managedCredentialType(m, "azure_rm")
+	if diags.HasError() {
+		return diags
+	}
+	inputs := expandCredentialAzureRMInputs(d)
+	if err := checkCredentialInputs(azureType, inputs); err != nil {
+		return buildDiagnosticsMessage("Unable to create new credentials", "Unable to create new credentials: %s", err.Error())
+	}
+	newCredential := map[string]interface{}{
+		"name":            d.Get("name").(string),
+		"description":     d.Get("description").(string),
+		"organization":    d.Get("organisation_id").(int),
+		"credential_type": azureType.ID,
+		"inputs":          inputs,
+	}
+
+	client := m.(*awx.AWX)
+	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
+	if err != nil {
+		return buildDiagAPIFail(
+			"Unable to create new credentials", err, credentialFieldAttributes,
+			"Unable to create new credentials: %s", err.Error(),
+		)
+	}
+
+	d.SetId(strconv.Itoa(cred.ID))
+	resourceCredentialAzureRMRead(ctx, d, m)
+
+	return diags
+}
+
+func resourceCredentialAzureRMRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*awx.AWX)
+	id, _ := strconv.Atoi(d.Id())
+	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
+	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "credentials", id)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to fetch credentials",
+			Detail:   fmt.Sprintf("Unable to credentials with id %d: %s", id, err.Error()),
+		})
+		return diags
+	}
+
+	d.Set("name", cred.Name)
+	d.Set("description", cred.Description)
+	d.Set("subscription_id", cred.Inputs["subscription"])
+	d.Set("client_id", cred.Inputs["client"])
+	setCredentialSecret(d, "client_secret", cred.Inputs["secret"])
+	d.Set("tenant_id", cred.Inputs["tenant"])
+	d.Set("username", cred.Inputs["username"])
+	setCredentialSecret(d, "password", cred.Inputs["password"])
+	d.Set("cloud_environment", cred.Inputs["cloud_environment"])
+	d.Set("organisation_id", cred.OrganizationID)
+
+	return diags
+}
+
+func resourceCredentialAzureRMUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	keys := []string{
+		"name",
+		"description",
+		"subscription_id",
+		"client_id",
+		"client_secret",
+		"tenant_id",
+		"username",
+		"password",
+		"cloud_environment",
+		"organisation_id",
+	}
+
+	if d.HasChanges(keys...) {
+		var err error
+
+		id, _ := strconv.Atoi(d.Id())
+		azureType, diags := managedCredentialType(m, "azure_rm")
+		if diags.HasError() {
+			return diags
+		}
+		inputs := expandCredentialAzureRMInputs(d)
+		if err := checkCredentialInputs(azureType, inputs); err != nil {
+			return buildDiagnosticsMessage("Unable to update existing credentials", "Unable to update existing credentials with id %d: %s", id, err.Error())
+		}
+		updatedCredential := map[string]interface{}{
+			"name":            d.Get("name").(string),
+			"description":     d.Get("description").(string),
+			"organization":    d.Get("organisation_id").(int),
+			"credential_type": azureType.ID,
+			"inputs":          inputs,
+		}
+
+		client := m.(*awx.AWX)
+		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
+		if err != nil {
+			return buildDiagAPIFail(
+				"Unable to update existing credentials", err, credentialFieldAttributes,
+				"Unable to update existing credentials with id %d: %s", id, err.Error(),
+			)
+		}
+	}
+
+	return resourceCredentialAzureRMRead(ctx, d, m)
+}
@@ -60,11 +60,15 @@ func resourceCredentialGoogleComputeEngineCreate(ctx context.Context, d *schema.
 	var diags diag.Diagnostics
 	var err error
 
+	credentialTypeID, diags := managedCredentialTypeID(m, "gce")
+	if diags.HasError() {
+		return diags
+	}
 	newCredential := map[string]interface{}{
 		"name":            d.Get("name").(string),
 		"description":     d.Get("description").(string),
 		"organization":    d.Get("organisation_id").(int),
-		"credential_type": 10, // Google Compute Engine
+		"credential_type": credentialTypeID,
 		"inputs": map[string]interface{}{
 			"username":     d.Get("username").(string),
 			"project":      d.Get("project").(string),
@@ -75,12 +79,10 @@ func resourceCredentialGoogleComputeEngineCreate(ctx context.Context, d *schema.
 	client := m.(*awx.AWX)
 	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credentials",
-			Detail:   fmt.Sprintf("Unable to create new credentials: %s", err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail(
+			"Unable to create new credentials", err, credentialFieldAttributes,
+			"Unable to create new credentials: %s", err.Error(),
+		)
 	}
 
 	d.SetId(strconv.Itoa(cred.ID))
@@ -96,6 +98,9 @@ func resourceCredentialGoogleComputeEngineRead(ctx context.Context, d *schema.Re
 	id, _ := strconv.Atoi(d.Id())
 	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
 	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "credentials", id)
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to fetch credentials",
@@ -114,8 +119,6 @@ func resourceCredentialGoogleComputeEngineRead(ctx context.Context, d *schema.Re
 }
 
 func resourceCredentialGoogleComputeEngineUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-
 	keys := []string{
 		"name",
 		"description",
@@ -129,11 +132,15 @@ func resourceCredentialGoogleComputeEngineUpdate(ctx context.Context, d *schema.
 		var err error
 
 		id, _ := strconv.Atoi(d.Id())
+		credentialTypeID, diags := managedCredentialTypeID(m, "gce")
+		if diags.HasError() {
+			return diags
+		}
 		updatedCredential := map[string]interface{}{
 			"name":            d.Get("name").(string),
 			"description":     d.Get("description").(string),
 			"organization":    d.Get("organisation_id").(int),
-			"credential_type": 10, // Google Compute Engine
+			"credential_type": credentialTypeID,
 			"inputs": map[string]interface{}{
 				"username":     d.Get("username").(string),
 				"project":      d.Get("project").(string),
@@ -144,12 +151,10 @@ func resourceCredentialGoogleComputeEngineUpdate(ctx context.Context, d *schema.
 		client := m.(*awx.AWX)
 		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credentials",
-				Detail:   fmt.Sprintf("Unable to update existing credentials with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return buildDiagAPIFail(
+				"Unable to update existing credentials", err, credentialFieldAttributes,
+				"Unable to update existing credentials with id %d: %s", id, err.Error(),
+			)
 		}
 	}
 
@@ -0,0 +1,196 @@
+/*
+Manages a HashiCorp Vault Secret Lookup credential, the source of
+awx_credential_input_source resources reading KV v1 or v2 secrets. It
+authenticates with exactly one of a token, an AppRole, a Kubernetes role or a
+client certificate.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_hashicorp_vault_lookup" "vault" {
+	  name              = "Vault"
+	  organisation_id   = awx_organization.default.id
+	  url               = "https://vault.example.com:8200"
+	  cacert            = file("vault-ca.pem")
+	  namespace         = "infra"
+	  role_id           = var.vault_role_id
+	  secret_id         = var.vault_secret_id
+	  default_auth_path = "approle"
+	  api_version       = "v2"
+	}
+
+	resource "awx_credential_input_source" "password" {
+	  input_field_name = "password"
+	  target           = awx_credential_machine.linux.id
+	  source           = awx_credential_hashicorp_vault_lookup.vault.id
+	  metadata = {
+	    secret_path = "/kv/linux"
+	    secret_key  = "password"
+	  }
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+func resourceCredentialHashicorpVaultLookup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCredentialHashicorpVaultLookupCreate,
+		ReadContext:   resourceCredentialHashicorpVaultLookupRead,
+		UpdateContext: resourceCredentialHashicorpVaultLookupUpdate,
+		DeleteContext: CredentialsServiceDeleteByID,
+		Schema: addHashicorpVaultSchema(map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"organisation_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"api_version": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "v2",
+				ValidateFunc: validation.StringInSlice([]string{"v1", "v2"}, false),
+				Description:  "Version of the KV secrets engine, v1 or v2",
+			},
+		}),
+	}
+}
+
+func expandCredentialHashicorpVaultLookupInputs(d *schema.ResourceData) map[string]interface{} {
+	inputs := expandHashicorpVaultInputs(d)
+	inputs["api_version"] = d.Get("api_version").(string)
+	return inputs
+}
+
+func resourceCredentialHashicorpVaultLookupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	vaultType, diags := managedCredentialType(m, "hashivault_kv")
+	if diags.HasError() {
+		return diags
+	}
+	inputs := expandCredentialHashicorpVaultLookupInputs(d)
+	if err := checkCredentialInputs(vaultType, inputs); err != nil {
+		return buildDiagnosticsMessage("Unable to create new credentials", "Unable to create new credentials: %s", err.Error())
+	}
+	newCredential := map[string]interface{}{
+		"name":            d.Get("name").(string),
+		"description":     d.Get("description").(string),
+		"organization":    d.Get("organisation_id").(int),
+		"credential_type": vaultType.ID,
+		"inputs":          inputs,
+	}
+
+	client := m.(*awx.AWX)
+	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
+	if err != nil {
+		return buildDiagAPIFail(
+			"Unable to create new credentials", err, credentialFieldAttributes,
+			"Unable to create new credentials: %s", err.Error(),
+		)
+	}
+
+	d.SetId(strconv.Itoa(cred.ID))
+	resourceCredentialHashicorpVaultLookupRead(ctx, d, m)
+
+	return diags
+}
+
+func resourceCredentialHashicorpVaultLookupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*awx.AWX)
+	id, _ := strconv.Atoi(d.Id())
+	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
+	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "credentials", id)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to fetch credentials",
+			Detail:   fmt.Sprintf("Unable to credentials with id %d: %s", id, err.Error()),
+		})
+		return diags
+	}
+
+	d.Set("name", cred.Name)
+	d.Set("description", cred.Description)
+	setHashicorpVaultResourceData(d, cred.Inputs)
+	d.Set("api_version", cred.Inputs["api_version"])
+	d.Set("organisation_id", cred.OrganizationID)
+
+	return diags
+}
+
+func resourceCredentialHashicorpVaultLookupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	keys := []string{
+		"name",
+		"description",
+		"url",
+		"cacert",
+		"namespace",
+		"token",
+		"role_id",
+		"secret_id",
+		"kubernetes_role",
+		"client_cert_public",
+		"client_cert_private",
+		"client_cert_role",
+		"default_auth_path",
+		"api_version",
+		"organisation_id",
+	}
+
+	if d.HasChanges(keys...) {
+		var err error
+
+		id, _ := strconv.Atoi(d.Id())
+		vaultType, diags := managedCredentialType(m, "hashivault_kv")
+		if diags.HasError() {
+			return diags
+		}
+		inputs := expandCredentialHashicorpVaultLookupInputs(d)
+		if err := checkCredentialInputs(vaultType, inputs); err != nil {
+			return buildDiagnosticsMessage("Unable to update existing credentials", "Unable to update existing credentials with id %d: %s", id, err.Error())
+		}
+		updatedCredential := map[string]interface{}{
+			"name":            d.Get("name").(string),
+			"description":     d.Get("description").(string),
+			"organization":    d.Get("organisation_id").(int),
+			"credential_type": vaultType.ID,
+			"inputs":          inputs,
+		}
+
+		client := m.(*awx.AWX)
+		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
+		if err != nil {
+			return buildDiagAPIFail(
+				"Unable to update existing credentials", err, credentialFieldAttributes,
+				"Unable to update existing credentials with id %d: %s", id, err.Error(),
+			)
+		}
+	}
+
+	return resourceCredentialHashicorpVaultLookupRead(ctx, d, m)
+}
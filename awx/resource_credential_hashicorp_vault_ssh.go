@@ -0,0 +1,177 @@
+/*
+Manages a HashiCorp Vault Signed SSH credential, the source of
+awx_credential_input_source resources signing the public key of machine
+credentials. It authenticates with exactly one of a token, an AppRole, a
+Kubernetes role or a client certificate.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_hashicorp_vault_ssh" "vault" {
+	  name            = "Vault SSH"
+	  organisation_id = awx_organization.default.id
+	  url             = "https://vault.example.com:8200"
+	  token           = var.vault_token
+	}
+
+	resource "awx_credential_input_source" "certificate" {
+	  input_field_name = "ssh_public_key_data"
+	  target           = awx_credential_machine.linux.id
+	  source           = awx_credential_hashicorp_vault_ssh.vault.id
+	  metadata = {
+	    secret_path      = "ssh-client-signer"
+	    role             = "awx"
+	    public_key       = file("~/.ssh/awx.pub")
+	    valid_principals = "ansible"
+	  }
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+func resourceCredentialHashicorpVaultSSH() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCredentialHashicorpVaultSSHCreate,
+		ReadContext:   resourceCredentialHashicorpVaultSSHRead,
+		UpdateContext: resourceCredentialHashicorpVaultSSHUpdate,
+		DeleteContext: CredentialsServiceDeleteByID,
+		Schema: addHashicorpVaultSchema(map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"organisation_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+		}),
+	}
+}
+
+func resourceCredentialHashicorpVaultSSHCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	vaultType, diags := managedCredentialType(m, "hashivault_ssh")
+	if diags.HasError() {
+		return diags
+	}
+	inputs := expandHashicorpVaultInputs(d)
+	if err := checkCredentialInputs(vaultType, inputs); err != nil {
+		return buildDiagnosticsMessage("Unable to create new credentials", "Unable to create new credentials: %s", err.Error())
+	}
+	newCredential := map[string]interface{}{
+		"name":            d.Get("name").(string),
+		"description":     d.Get("description").(string),
+		"organization":    d.Get("organisation_id").(int),
+		"credential_type": vaultType.ID,
+		"inputs":          inputs,
+	}
+
+	client := m.(*awx.AWX)
+	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
+	if err != nil {
+		return buildDiagAPIFail(
+			"Unable to create new credentials", err, credentialFieldAttributes,
+			"Unable to create new credentials: %s", err.Error(),
+		)
+	}
+
+	d.SetId(strconv.Itoa(cred.ID))
+	resourceCredentialHashicorpVaultSSHRead(ctx, d, m)
+
+	return diags
+}
+
+func resourceCredentialHashicorpVaultSSHRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*awx.AWX)
+	id, _ := strconv.Atoi(d.Id())
+	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
+	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "credentials", id)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to fetch credentials",
+			Detail:   fmt.Sprintf("Unable to credentials with id %d: %s", id, err.Error()),
+		})
+		return diags
+	}
+
+	d.Set("name", cred.Name)
+	d.Set("description", cred.Description)
+	setHashicorpVaultResourceData(d, cred.Inputs)
+	d.Set("organisation_id", cred.OrganizationID)
+
+	return diags
+}
+
+func resourceCredentialHashicorpVaultSSHUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	keys := []string{
+		"name",
+		"description",
+		"url",
+		"cacert",
+		"namespace",
+		"token",
+		"role_id",
+		"secret_id",
+		"kubernetes_role",
+		"client_cert_public",
+		"client_cert_private",
+		"client_cert_role",
+		"default_auth_path",
+		"organisation_id",
+	}
+
+	if d.HasChanges(keys...) {
+		var err error
+
+		id, _ := strconv.Atoi(d.Id())
+		vaultType, diags := managedCredentialType(m, "hashivault_ssh")
+		if diags.HasError() {
+			return diags
+		}
+		inputs := expandHashicorpVaultInputs(d)
+		if err := checkCredentialInputs(vaultType, inputs); err != nil {
+			return buildDiagnosticsMessage("Unable to update existing credentials", "Unable to update existing credentials with id %d: %s", id, err.Error())
+		}
+		updatedCredential := map[string]interface{}{
+			"name":            d.Get("name").(string),
+			"description":     d.Get("description").(string),
+			"organization":    d.Get("organisation_id").(int),
+			"credential_type": vaultType.ID,
+			"inputs":          inputs,
+		}
+
+		client := m.(*awx.AWX)
+		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
+		if err != nil {
+			return buildDiagAPIFail(
+				"Unable to update existing credentials", err, credentialFieldAttributes,
+				"Unable to update existing credentials with id %d: %s", id, err.Error(),
+			)
+		}
+	}
+
+	return resourceCredentialHashicorpVaultSSHRead(ctx, d, m)
+}
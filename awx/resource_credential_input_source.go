@@ -1,10 +1,24 @@
 /*
-*TBD*
+Sets an input of the target credential from a secret management system, the
+source credential, like awx_credential_hashicorp_vault_lookup. The metadata is
+checked against the metadata fields the credential type of the source declares,
+like secret_path, secret_key and secret_version for HashiCorp Vault Secret
+Lookup or secret_path, role, public_key and valid_principals for HashiCorp
+Vault Signed SSH.
 
 Example Usage
 
 ```hcl
-*TBD*
+resource "awx_credential_input_source" "password" {
+  input_field_name = "password"
+  target           = awx_credential_machine.linux.id
+  source           = awx_credential_hashicorp_vault_lookup.vault.id
+  metadata = {
+    secret_path    = "/kv/linux"
+    secret_key     = "password"
+    secret_version = "3"
+  }
+}
 ```
 
 */
@@ -13,7 +27,9 @@ package awx
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -26,6 +42,7 @@ func resourceCredentialInputSource() *schema.Resource {
 		ReadContext:   resourceCredentialInputSourceRead,
 		UpdateContext: resourceCredentialInputSourceUpdate,
 		DeleteContext: resourceCredentialInputSourceDelete,
+		CustomizeDiff: resourceCredentialInputSourceCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"description": &schema.Schema{
 				Type:     schema.TypeString,
@@ -48,16 +65,80 @@ func resourceCredentialInputSource() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Optional: true,
+				Optional:    true,
+				Description: "Metadata of the lookup, the credential type of the source declares the keys",
 			},
 		},
 	}
 }
 
+// resourceCredentialInputSourceCustomizeDiff checks new or changed metadata as
+// soon as the source credential exists.
+func resourceCredentialInputSourceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChanges("source", "metadata") {
+		return nil
+	}
+	if !d.NewValueKnown("source") || !d.NewValueKnown("metadata") {
+		return nil
+	}
+	return checkCredentialInputSourceMetadata(m, d.Get("source").(int), d.Get("metadata").(map[string]interface{}))
+}
+
+// checkCredentialInputSourceMetadata fails unless the credential type of the
+// source credential declares every key of metadata and metadata has all the
+// required keys.
+func checkCredentialInputSourceMetadata(m interface{}, source int, metadata map[string]interface{}) error {
+	cred := new(credential)
+	if err := apiGet(m, fmt.Sprintf("%s%d/", credentialsAPIEndpoint, source), cred, nil); err != nil {
+		return fmt.Errorf("unable to fetch source credential %d: %s", source, err)
+	}
+	t, err := getCredentialType(m, cred.CredentialType)
+	if err != nil {
+		return fmt.Errorf("unable to fetch credential type %d of source credential %d: %s", cred.CredentialType, source, err)
+	}
+	if t.Kind != "external" {
+		return fmt.Errorf("source credential %d has credential type %s, which is no secret management system", source, t.Name)
+	}
+
+	fields := map[string]credentialTypeField{}
+	var declared []string
+	for _, field := range t.Inputs.Metadata {
+		fields[field.ID] = field
+		declared = append(declared, field.ID)
+	}
+	var problems []string
+	for key, value := range metadata {
+		field, ok := fields[key]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown key %s, %s declares %s", key, t.Name, strings.Join(declared, ", ")))
+			continue
+		}
+		if len(field.Choices) > 0 && !containsString(field.Choices, value.(string)) {
+			problems = append(problems, fmt.Sprintf("%s must be one of %s", key, strings.Join(field.Choices, ", ")))
+		}
+	}
+	for _, key := range t.Inputs.Required {
+		if _, ok := fields[key]; !ok {
+			continue
+		}
+		if v, _ := metadata[key].(string); v == "" {
+			problems = append(problems, fmt.Sprintf("%s is required by %s", key, t.Name))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("invalid metadata: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
 func resourceCredentialInputSourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	var err error
 
+	if err := checkCredentialInputSourceMetadata(m, d.Get("source").(int), d.Get("metadata").(map[string]interface{})); err != nil {
+		return buildDiagnosticsMessage("Unable to create new credentials", "Unable to create new credentials: %s", err.Error())
+	}
 	newSourceInput := map[string]interface{}{
 		"description":       d.Get("description").(string),
 		"input_field_name":  d.Get("input_field_name").(string),
@@ -69,12 +150,10 @@ func resourceCredentialInputSourceCreate(ctx context.Context, d *schema.Resource
 	client := m.(*awx.AWX)
 	cred, err := client.CredentialInputSourceService.CreateCredentialInputSource(newSourceInput, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credentials",
-			Detail:   fmt.Sprintf("Unable to create new credentials: %s", err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail(
+			"Unable to create new credentials", err, nil,
+			"Unable to create new credentials: %s", err.Error(),
+		)
 	}
 
 	d.SetId(strconv.Itoa(cred.ID))
@@ -90,6 +169,9 @@ func resourceCredentialInputSourceRead(ctx context.Context, d *schema.ResourceDa
 	id, _ := strconv.Atoi(d.Id())
 	inputSource, err := client.CredentialInputSourceService.GetCredentialInputSourceByID(id, map[string]string{})
 	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "credential input source", id)
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to fetch credentials",
@@ -108,8 +190,6 @@ func resourceCredentialInputSourceRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceCredentialInputSourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-
 	keys := []string{
 		"description",
 		"input_field_name",
@@ -122,6 +202,11 @@ func resourceCredentialInputSourceUpdate(ctx context.Context, d *schema.Resource
 		var err error
 
 		id, _ := strconv.Atoi(d.Id())
+		if d.HasChanges("source", "metadata") {
+			if err := checkCredentialInputSourceMetadata(m, d.Get("source").(int), d.Get("metadata").(map[string]interface{})); err != nil {
+				return buildDiagnosticsMessage("Unable to update existing credentials", "Unable to update existing credentials with id %d: %s", id, err.Error())
+			}
+		}
 		updatedSourceInput := map[string]interface{}{
 			"description":       d.Get("description").(string),
 			"input_field_name":  d.Get("input_field_name").(string),
@@ -133,12 +218,10 @@ func resourceCredentialInputSourceUpdate(ctx context.Context, d *schema.Resource
 		client := m.(*awx.AWX)
 		_, err = client.CredentialInputSourceService.UpdateCredentialInputSourceByID(id, updatedSourceInput, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credentials",
-				Detail:   fmt.Sprintf("Unable to update existing credentials with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return buildDiagAPIFail(
+				"Unable to update existing credentials", err, nil,
+				"Unable to update existing credentials with id %d: %s", id, err.Error(),
+			)
 		}
 	}
 
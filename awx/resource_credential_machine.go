@@ -83,11 +83,15 @@ func resourceCredentialMachineCreate(ctx context.Context, d *schema.ResourceData
 	var diags diag.Diagnostics
 	var err error
 
+	credentialTypeID, diags := managedCredentialTypeID(m, "ssh")
+	if diags.HasError() {
+		return diags
+	}
 	newCredential := map[string]interface{}{
 		"name":            d.Get("name").(string),
 		"description":     d.Get("description").(string),
 		"organization":    d.Get("organisation_id").(int),
-		"credential_type": 1, // SSH
+		"credential_type": credentialTypeID,
 		"inputs": map[string]interface{}{
 			"username":            d.Get("username").(string),
 			"password":            d.Get("password").(string),
@@ -103,12 +107,10 @@ func resourceCredentialMachineCreate(ctx context.Context, d *schema.ResourceData
 	client := m.(*awx.AWX)
 	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credentials",
-			Detail:   fmt.Sprintf("Unable to create new credentials: %s", err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail(
+			"Unable to create new credentials", err, credentialFieldAttributes,
+			"Unable to create new credentials: %s", err.Error(),
+		)
 	}
 
 	d.SetId(strconv.Itoa(cred.ID))
@@ -124,6 +126,9 @@ func resourceCredentialMachineRead(ctx context.Context, d *schema.ResourceData,
 	id, _ := strconv.Atoi(d.Id())
 	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
 	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "credentials", id)
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to fetch credentials",
@@ -148,8 +153,6 @@ func resourceCredentialMachineRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceCredentialMachineUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-
 	keys := []string{
 		"name",
 		"description",
@@ -170,11 +173,15 @@ func resourceCredentialMachineUpdate(ctx context.Context, d *schema.ResourceData
 		var err error
 
 		id, _ := strconv.Atoi(d.Id())
+		credentialTypeID, diags := managedCredentialTypeID(m, "ssh")
+		if diags.HasError() {
+			return diags
+		}
 		updatedCredential := map[string]interface{}{
 			"name":            d.Get("name").(string),
 			"description":     d.Get("description").(string),
 			"organization":    d.Get("organisation_id").(int),
-			"credential_type": 1, // SSH
+			"credential_type": credentialTypeID,
 			"inputs": map[string]interface{}{
 				"username":            d.Get("username").(string),
 				"password":            d.Get("password").(string),
@@ -190,12 +197,10 @@ func resourceCredentialMachineUpdate(ctx context.Context, d *schema.ResourceData
 		client := m.(*awx.AWX)
 		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credentials",
-				Detail:   fmt.Sprintf("Unable to update existing credentials with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return buildDiagAPIFail(
+				"Unable to update existing credentials", err, credentialFieldAttributes,
+				"Unable to update existing credentials with id %d: %s", id, err.Error(),
+			)
 		}
 	}
 
@@ -0,0 +1,230 @@
+/*
+Manages a Network credential for the network modules, with a password or
+an SSH key and optionally the password to enter privileged mode. AWX refuses
+authorize_password without authorize and ssh_key_unlock without ssh_key_data,
+plans fail for both.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_network" "switches" {
+	  name               = "Switches"
+	  organisation_id    = awx_organization.default.id
+	  username           = "netops"
+	  ssh_key_data       = file("~/.ssh/netops")
+	  authorize          = true
+	  authorize_password = var.enable_password
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+func resourceCredentialNetwork() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCredentialNetworkCreate,
+		ReadContext:   resourceCredentialNetworkRead,
+		UpdateContext: resourceCredentialNetworkUpdate,
+		DeleteContext: CredentialsServiceDeleteByID,
+		CustomizeDiff: resourceCredentialNetworkCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"organisation_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Username of the network devices",
+			},
+			"password": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password of the network devices",
+			},
+			"ssh_key_data": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`), "must be a PEM encoded private key"),
+				Description:  "Private SSH key of the network devices",
+			},
+			"ssh_key_unlock": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"ssh_key_data"},
+				Description:  "Passphrase of the private SSH key",
+			},
+			"authorize": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enter privileged mode on the network devices",
+			},
+			"authorize_password": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password to enter privileged mode, requires authorize",
+			},
+		},
+	}
+}
+
+func expandCredentialNetworkInputs(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"username":           d.Get("username").(string),
+		"password":           d.Get("password").(string),
+		"ssh_key_data":       d.Get("ssh_key_data").(string),
+		"ssh_key_unlock":     d.Get("ssh_key_unlock").(string),
+		"authorize":          d.Get("authorize").(bool),
+		"authorize_password": d.Get("authorize_password").(string),
+	}
+}
+
+// resourceCredentialNetworkCustomizeDiff rejects an authorize_password
+// without authorize, AWX refuses it.
+func resourceCredentialNetworkCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("authorize") || !d.NewValueKnown("authorize_password") {
+		return nil
+	}
+	if d.Get("authorize_password").(string) != "" && !d.Get("authorize").(bool) {
+		return fmt.Errorf("authorize_password requires authorize = true")
+	}
+	return nil
+}
+
+func resourceCredentialNetworkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	networkType, diags := managedCredentialType(m, "net")
+	if diags.HasError() {
+		return diags
+	}
+	inputs := expandCredentialNetworkInputs(d)
+	if err := checkCredentialInputs(networkType, inputs); err != nil {
+		return buildDiagnosticsMessage("Unable to create new credentials", "Unable to create new credentials: %s", err.Error())
+	}
+	newCredential := map[string]interface{}{
+		"name":            d.Get("name").(string),
+		"description":     d.Get("description").(string),
+		"organization":    d.Get("organisation_id").(int),
+		"credential_type": networkType.ID,
+		"inputs":          inputs,
+	}
+
+	client := m.(*awx.AWX)
+	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
+	if err != nil {
+		return buildDiagAPIFail(
+			"Unable to create new credentials", err, credentialFieldAttributes,
+			"Unable to create new credentials: %s", err.Error(),
+		)
+	}
+
+	d.SetId(strconv.Itoa(cred.ID))
+	resourceCredentialNetworkRead(ctx, d, m)
+
+	return diags
+}
+
+func resourceCredentialNetworkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*awx.AWX)
+	id, _ := strconv.Atoi(d.Id())
+	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
+	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "credentials", id)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to fetch credentials",
+			Detail:   fmt.Sprintf("Unable to credentials with id %d: %s", id, err.Error()),
+		})
+		return diags
+	}
+
+	d.Set("name", cred.Name)
+	d.Set("description", cred.Description)
+	d.Set("username", cred.Inputs["username"])
+	setCredentialSecret(d, "password", cred.Inputs["password"])
+	setCredentialSecret(d, "ssh_key_data", cred.Inputs["ssh_key_data"])
+	setCredentialSecret(d, "ssh_key_unlock", cred.Inputs["ssh_key_unlock"])
+	d.Set("authorize", cred.Inputs["authorize"])
+	setCredentialSecret(d, "authorize_password", cred.Inputs["authorize_password"])
+	d.Set("organisation_id", cred.OrganizationID)
+
+	return diags
+}
+
+func resourceCredentialNetworkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	keys := []string{
+		"name",
+		"description",
+		"username",
+		"password",
+		"ssh_key_data",
+		"ssh_key_unlock",
+		"authorize",
+		"authorize_password",
+		"organisation_id",
+	}
+
+	if d.HasChanges(keys...) {
+		var err error
+
+		id, _ := strconv.Atoi(d.Id())
+		networkType, diags := managedCredentialType(m, "net")
+		if diags.HasError() {
+			return diags
+		}
+		inputs := expandCredentialNetworkInputs(d)
+		if err := checkCredentialInputs(networkType, inputs); err != nil {
+			return buildDiagnosticsMessage("Unable to update existing credentials", "Unable to update existing credentials with id %d: %s", id, err.Error())
+		}
+		updatedCredential := map[string]interface{}{
+			"name":            d.Get("name").(string),
+			"description":     d.Get("description").(string),
+			"organization":    d.Get("organisation_id").(int),
+			"credential_type": networkType.ID,
+			"inputs":          inputs,
+		}
+
+		client := m.(*awx.AWX)
+		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
+		if err != nil {
+			return buildDiagAPIFail(
+				"Unable to update existing credentials", err, credentialFieldAttributes,
+				"Unable to update existing credentials with id %d: %s", id, err.Error(),
+			)
+		}
+	}
+
+	return resourceCredentialNetworkRead(ctx, d, m)
+}
@@ -0,0 +1,262 @@
+/*
+Manages an OpenStack credential for OpenStack inventory sources and playbooks.
+The cloud block takes the auth and region settings of a clouds.yaml entry.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_openstack" "private" {
+	  name            = "OpenStack"
+	  organisation_id = awx_organization.default.id
+
+	  cloud {
+	    auth_url            = "https://keystone.example.com:5000/v3"
+	    username            = "awx"
+	    password            = var.openstack_password
+	    project_name        = "infra"
+	    project_domain_name = "Default"
+	    user_domain_name    = "Default"
+	    region_name         = "RegionOne"
+	    verify_ssl          = true
+	  }
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+// openstackCloudInputs maps the clouds.yaml keys of the cloud block to the
+// inputs of the OpenStack credential type.
+var openstackCloudInputs = map[string]string{
+	"auth_url":            "host",
+	"username":            "username",
+	"password":            "password",
+	"project_name":        "project",
+	"project_domain_name": "project_domain_name",
+	"user_domain_name":    "domain",
+	"region_name":         "region",
+}
+
+func resourceCredentialOpenStack() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCredentialOpenStackCreate,
+		ReadContext:   resourceCredentialOpenStackRead,
+		UpdateContext: resourceCredentialOpenStackUpdate,
+		DeleteContext: CredentialsServiceDeleteByID,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"organisation_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"cloud": &schema.Schema{
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "Auth and region settings, named like in clouds.yaml",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"auth_url": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+							Description:  "URL of the Keystone identity service",
+						},
+						"username": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							Description:  "Username of the OpenStack user",
+						},
+						"password": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							Description:  "Password of the OpenStack user",
+						},
+						"project_name": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							Description:  "Name of the project, also known as tenant",
+						},
+						"project_domain_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Domain of the project, Keystone v3 only",
+						},
+						"user_domain_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Domain of the user, Keystone v3 only",
+						},
+						"region_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Name of the region",
+						},
+						"verify_ssl": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Verify the certificate of the OpenStack endpoints",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandCredentialOpenStackInputs(d *schema.ResourceData) map[string]interface{} {
+	cloud := d.Get("cloud.0").(map[string]interface{})
+	inputs := map[string]interface{}{
+		"verify_ssl": cloud["verify_ssl"].(bool),
+	}
+	for key, input := range openstackCloudInputs {
+		inputs[input] = cloud[key].(string)
+	}
+	return inputs
+}
+
+// flattenCredentialOpenStackCloud returns the cloud block of the inputs AWX
+// returns, with the password of the state as AWX returns it encrypted.
+func flattenCredentialOpenStackCloud(d *schema.ResourceData, inputs map[string]interface{}) []interface{} {
+	cloud := map[string]interface{}{
+		"verify_ssl": true,
+	}
+	if v, ok := inputs["verify_ssl"].(bool); ok {
+		cloud["verify_ssl"] = v
+	}
+	for key, input := range openstackCloudInputs {
+		if v, ok := inputs[input].(string); ok {
+			cloud[key] = v
+		}
+	}
+	if cloud["password"] == encryptedInput {
+		cloud["password"] = d.Get("cloud.0.password").(string)
+	}
+	return []interface{}{cloud}
+}
+
+func resourceCredentialOpenStackCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	openstackType, diags := managedCredentialType(m, "openstack")
+	if diags.HasError() {
+		return diags
+	}
+	inputs := expandCredentialOpenStackInputs(d)
+	if err := checkCredentialInputs(openstackType, inputs); err != nil {
+		return buildDiagnosticsMessage("Unable to create new credentials", "Unable to create new credentials: %s", err.Error())
+	}
+	newCredential := map[string]interface{}{
+		"name":            d.Get("name").(string),
+		"description":     d.Get("description").(string),
+		"organization":    d.Get("organisation_id").(int),
+		"credential_type": openstackType.ID,
+		"inputs":          inputs,
+	}
+
+	client := m.(*awx.AWX)
+	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
+	if err != nil {
+		return buildDiagAPIFail(
+			"Unable to create new credentials", err, credentialFieldAttributes,
+			"Unable to create new credentials: %s", err.Error(),
+		)
+	}
+
+	d.SetId(strconv.Itoa(cred.ID))
+	resourceCredentialOpenStackRead(ctx, d, m)
+
+	return diags
+}
+
+func resourceCredentialOpenStackRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*awx.AWX)
+	id, _ := strconv.Atoi(d.Id())
+	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
+	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "credentials", id)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to fetch credentials",
+			Detail:   fmt.Sprintf("Unable to credentials with id %d: %s", id, err.Error()),
+		})
+		return diags
+	}
+
+	d.Set("name", cred.Name)
+	d.Set("description", cred.Description)
+	d.Set("cloud", flattenCredentialOpenStackCloud(d, cred.Inputs))
+	d.Set("organisation_id", cred.OrganizationID)
+
+	return diags
+}
+
+func resourceCredentialOpenStackUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	keys := []string{
+		"name",
+		"description",
+		"cloud",
+		"organisation_id",
+	}
+
+	if d.HasChanges(keys...) {
+		var err error
+
+		id, _ := strconv.Atoi(d.Id())
+		openstackType, diags := managedCredentialType(m, "openstack")
+		if diags.HasError() {
+			return diags
+		}
+		inputs := expandCredentialOpenStackInputs(d)
+		if err := checkCredentialInputs(openstackType, inputs); err != nil {
+			return buildDiagnosticsMessage("Unable to update existing credentials", "Unable to update existing credentials with id %d: %s", id, err.Error())
+		}
+		updatedCredential := map[string]interface{}{
+			"name":            d.Get("name").(string),
+			"description":     d.Get("description").(string),
+			"organization":    d.Get("organisation_id").(int),
+			"credential_type": openstackType.ID,
+			"inputs":          inputs,
+		}
+
+		client := m.(*awx.AWX)
+		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
+		if err != nil {
+			return buildDiagAPIFail(
+				"Unable to update existing credentials", err, credentialFieldAttributes,
+				"Unable to update existing credentials with id %d: %s", id, err.Error(),
+			)
+		}
+	}
+
+	return resourceCredentialOpenStackRead(ctx, d, m)
+}
@@ -0,0 +1,183 @@
+/*
+Manages a Red Hat Satellite 6 credential for Satellite inventory sources.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_satellite" "satellite" {
+	  name            = "Satellite"
+	  organisation_id = awx_organization.default.id
+	  host            = "https://satellite.example.com"
+	  username        = "awx"
+	  password        = var.satellite_password
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+func resourceCredentialSatellite() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCredentialSatelliteCreate,
+		ReadContext:   resourceCredentialSatelliteRead,
+		UpdateContext: resourceCredentialSatelliteUpdate,
+		DeleteContext: CredentialsServiceDeleteByID,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"organisation_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"host": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "URL of the Satellite server, like https://satellite.example.com",
+			},
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Username of the Satellite user",
+			},
+			"password": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Password of the Satellite user",
+			},
+		},
+	}
+}
+
+func expandCredentialSatelliteInputs(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"host":     d.Get("host").(string),
+		"username": d.Get("username").(string),
+		"password": d.Get("password").(string),
+	}
+}
+
+func resourceCredentialSatelliteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	satelliteType, diags := managedCredentialType(m, "satellite6")
+	if diags.HasError() {
+		return diags
+	}
+	inputs := expandCredentialSatelliteInputs(d)
+	if err := checkCredentialInputs(satelliteType, inputs); err != nil {
+		return buildDiagnosticsMessage("Unable to create new credentials", "Unable to create new credentials: %s", err.Error())
+	}
+	newCredential := map[string]interface{}{
+		"name":            d.Get("name").(string),
+		"description":     d.Get("description").(string),
+		"organization":    d.Get("organisation_id").(int),
+		"credential_type": satelliteType.ID,
+		"inputs":          inputs,
+	}
+
+	client := m.(*awx.AWX)
+	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
+	if err != nil {
+		return buildDiagAPIFail(
+			"Unable to create new credentials", err, credentialFieldAttributes,
+			"Unable to create new credentials: %s", err.Error(),
+		)
+	}
+
+	d.SetId(strconv.Itoa(cred.ID))
+	resourceCredentialSatelliteRead(ctx, d, m)
+
+	return diags
+}
+
+func resourceCredentialSatelliteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*awx.AWX)
+	id, _ := strconv.Atoi(d.Id())
+	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
+	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "credentials", id)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to fetch credentials",
+			Detail:   fmt.Sprintf("Unable to credentials with id %d: %s", id, err.Error()),
+		})
+		return diags
+	}
+
+	d.Set("name", cred.Name)
+	d.Set("description", cred.Description)
+	d.Set("host", cred.Inputs["host"])
+	d.Set("username", cred.Inputs["username"])
+	setCredentialSecret(d, "password", cred.Inputs["password"])
+	d.Set("organisation_id", cred.OrganizationID)
+
+	return diags
+}
+
+func resourceCredentialSatelliteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	keys := []string{
+		"name",
+		"description",
+		"host",
+		"username",
+		"password",
+		"organisation_id",
+	}
+
+	if d.HasChanges(keys...) {
+		var err error
+
+		id, _ := strconv.Atoi(d.Id())
+		satelliteType, diags := managedCredentialType(m, "satellite6")
+		if diags.HasError() {
+			return diags
+		}
+		inputs := expandCredentialSatelliteInputs(d)
+		if err := checkCredentialInputs(satelliteType, inputs); err != nil {
+			return buildDiagnosticsMessage("Unable to update existing credentials", "Unable to update existing credentials with id %d: %s", id, err.Error())
+		}
+		updatedCredential := map[string]interface{}{
+			"name":            d.Get("name").(string),
+			"description":     d.Get("description").(string),
+			"organization":    d.Get("organisation_id").(int),
+			"credential_type": satelliteType.ID,
+			"inputs":          inputs,
+		}
+
+		client := m.(*awx.AWX)
+		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
+		if err != nil {
+			return buildDiagAPIFail(
+				"Unable to update existing credentials", err, credentialFieldAttributes,
+				"Unable to update existing credentials with id %d: %s", id, err.Error(),
+			)
+		}
+	}
+
+	return resourceCredentialSatelliteRead(ctx, d, m)
+}
@@ -66,11 +66,15 @@ func resourceCredentialSCMCreate(ctx context.Context, d *schema.ResourceData, m
 	var diags diag.Diagnostics
 	var err error
 
+	credentialTypeID, diags := managedCredentialTypeID(m, "scm")
+	if diags.HasError() {
+		return diags
+	}
 	newCredential := map[string]interface{}{
 		"name":            d.Get("name").(string),
 		"description":     d.Get("description").(string),
 		"organization":    d.Get("organisation_id").(int),
-		"credential_type": 2, // Source Controll
+		"credential_type": credentialTypeID,
 		"inputs": map[string]interface{}{
 			"username":       d.Get("username").(string),
 			"password":       d.Get("password").(string),
@@ -82,12 +86,10 @@ func resourceCredentialSCMCreate(ctx context.Context, d *schema.ResourceData, m
 	client := m.(*awx.AWX)
 	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credentials",
-			Detail:   fmt.Sprintf("Unable to create new credentials: %s", err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail(
+			"Unable to create new credentials", err, credentialFieldAttributes,
+			"Unable to create new credentials: %s", err.Error(),
+		)
 	}
 
 	d.SetId(strconv.Itoa(cred.ID))
@@ -103,6 +105,9 @@ func resourceCredentialSCMRead(ctx context.Context, d *schema.ResourceData, m in
 	id, _ := strconv.Atoi(d.Id())
 	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
 	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "credentials", id)
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to fetch credentials",
@@ -123,8 +128,6 @@ func resourceCredentialSCMRead(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceCredentialSCMUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-
 	keys := []string{
 		"name",
 		"description",
@@ -139,11 +142,15 @@ func resourceCredentialSCMUpdate(ctx context.Context, d *schema.ResourceData, m
 		var err error
 
 		id, _ := strconv.Atoi(d.Id())
+		credentialTypeID, diags := managedCredentialTypeID(m, "scm")
+		if diags.HasError() {
+			return diags
+		}
 		updatedCredential := map[string]interface{}{
 			"name":            d.Get("name").(string),
 			"description":     d.Get("description").(string),
 			"organization":    d.Get("organisation_id").(int),
-			"credential_type": 2, // Source Controll
+			"credential_type": credentialTypeID,
 			"inputs": map[string]interface{}{
 				"username":       d.Get("username").(string),
 				"password":       d.Get("password").(string),
@@ -155,12 +162,10 @@ func resourceCredentialSCMUpdate(ctx context.Context, d *schema.ResourceData, m
 		client := m.(*awx.AWX)
 		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credentials",
-				Detail:   fmt.Sprintf("Unable to update existing credentials with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return buildDiagAPIFail(
+				"Unable to update existing credentials", err, credentialFieldAttributes,
+				"Unable to update existing credentials with id %d: %s", id, err.Error(),
+			)
 		}
 	}
 
@@ -0,0 +1,502 @@
+/*
+Manages a custom credential type, the inputs its credentials take and how the
+inputs are injected into jobs. Injector templates may only reference the
+declared inputs and the files of the file injector as tower.filename, plans
+fail for any other variable.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_type" "cmdb" {
+	  name = "CMDB"
+	  kind = "cloud"
+
+	  input {
+	    id    = "url"
+	    label = "CMDB URL"
+	  }
+
+	  input {
+	    id     = "token"
+	    label  = "API token"
+	    secret = true
+	  }
+
+	  required_inputs = ["url", "token"]
+
+	  injectors {
+	    env = {
+	      CMDB_URL   = "{{ url }}"
+	      CMDB_TOKEN = "{{ token }}"
+	    }
+	    extra_vars = {
+	      cmdb_config = "{{ tower.filename }}"
+	    }
+	    file = {
+	      template = "[cmdb]\nurl={{ url }}"
+	    }
+	  }
+	}
+
+	resource "awx_credential" "cmdb" {
+	  name               = "CMDB"
+	  organisation_id    = awx_organization.default.id
+	  credential_type_id = awx_credential_type.cmdb.id
+
+	  inputs = {
+	    url   = "https://cmdb.example.com"
+	    token = var.cmdb_token
+	  }
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// injectorTagPattern matches the expressions and statements of an injector
+// template, like {{ url | lower }} or {% if token %}.
+var injectorTagPattern = regexp.MustCompile(`(?s)\{\{(.*?)\}\}|\{%(.*?)%\}`)
+
+// injectorTokenPattern splits the tags of an injector template into string
+// literals, names, numbers and operators.
+var injectorTokenPattern = regexp.MustCompile(`'(?:[^'\\]|\\.)*'|"(?:[^"\\]|\\.)*"|[A-Za-z_][A-Za-z0-9_]*|[0-9][0-9.]*|==|!=|\S`)
+
+// injectorBuiltins are the variables AWX provides to injector templates
+// besides the inputs, tower.filename points to the files of the file injector.
+var injectorBuiltins = map[string]bool{"tower": true, "awx": true}
+
+// injectorJinjaNames are the keywords, literals and globals of Jinja, they
+// are no variables.
+var injectorJinjaNames = map[string]bool{
+	"if": true, "elif": true, "else": true, "endif": true, "for": true, "endfor": true, "in": true,
+	"not": true, "and": true, "or": true, "is": true, "set": true, "endset": true, "with": true,
+	"endwith": true, "recursive": true, "raw": true, "endraw": true, "filter": true, "endfilter": true,
+	"true": true, "false": true, "none": true, "True": true, "False": true, "None": true,
+	"loop": true, "range": true, "lipsum": true, "dict": true, "cycler": true, "joiner": true, "namespace": true,
+}
+
+var credentialTypeFieldAttributes = map[string]string{
+	"inputs":    "input",
+	"injectors": "injectors",
+}
+
+func resourceCredentialType() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCredentialTypeCreate,
+		ReadContext:   resourceCredentialTypeRead,
+		UpdateContext: resourceCredentialTypeUpdate,
+		DeleteContext: resourceCredentialTypeDelete,
+		CustomizeDiff: resourceCredentialTypeCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"kind": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"cloud", "net"}, false),
+				Description:  "One of cloud, net",
+			},
+			"input": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Input fields of the credentials, in the order AWX shows them",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`), "must be a valid variable name"),
+							Description:  "Id of the input, the name injector templates reference it by",
+						},
+						"label": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Label of the input",
+						},
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "string",
+							ValidateFunc: validation.StringInSlice([]string{"string", "boolean"}, false),
+							Description:  "One of string, boolean",
+						},
+						"secret": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "AWX encrypts the input and never returns it",
+						},
+						"multiline": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Show a text area for the input",
+						},
+						"help_text": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "",
+						},
+						"format": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "",
+							ValidateFunc: validation.StringInSlice([]string{"", "ssh_private_key", "url"}, false),
+							Description:  "One of ssh_private_key, url, validates the input",
+						},
+						"choices": &schema.Schema{
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Values the input is restricted to",
+						},
+					},
+				},
+			},
+			"required_inputs": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Ids of the inputs every credential has to set",
+			},
+			"injectors": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Injects the inputs into the jobs using the credentials, values are Jinja templates",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"env": &schema.Schema{
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Environment variables of the jobs",
+						},
+						"extra_vars": &schema.Schema{
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Extra variables of the jobs, values AWX holds as lists, objects, numbers or booleans read back JSON encoded",
+						},
+						"file": &schema.Schema{
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Files written for the jobs, template or template.<name> as keys, referenced as tower.filename or tower.filename.<name>",
+						},
+					},
+				},
+			},
+			"namespace": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Namespace of the credential type, empty for custom types",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceCredentialTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	result := new(credentialType)
+	if err := apiPost(m, credentialTypesAPIEndpoint, expandCredentialType(d), result); err != nil {
+		log.Printf("Fail to create CredentialType %v", err)
+		return buildDiagAPIFail(
+			"Unable to create CredentialType", err, credentialTypeFieldAttributes,
+			"CredentialType %s faild to create %s", d.Get("name").(string), err.Error(),
+		)
+	}
+	d.SetId(strconv.Itoa(result.ID))
+	return resourceCredentialTypeRead(ctx, d, m)
+}
+
+func resourceCredentialTypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Update CredentialType", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := apiPatch(m, fmt.Sprintf("%s%d/", credentialTypesAPIEndpoint, id), expandCredentialType(d), nil); err != nil {
+		return buildDiagAPIFail(
+			"Unable to update CredentialType", err, credentialTypeFieldAttributes,
+			"CredentialType with id %d faild to update %s", id, err.Error(),
+		)
+	}
+	return resourceCredentialTypeRead(ctx, d, m)
+}
+
+func resourceCredentialTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read CredentialType", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	res, err := getCredentialType(m, id)
+	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "credential type", id)
+		}
+		return buildDiagNotFoundFail("credential type", id, err)
+	}
+	setCredentialTypeResourceData(d, res)
+	return diags
+}
+
+func resourceCredentialTypeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Delete CredentialType", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := apiDelete(m, fmt.Sprintf("%s%d/", credentialTypesAPIEndpoint, id)); err != nil {
+		return buildDiagDeleteFail(
+			"CredentialType",
+			fmt.Sprintf("CredentialTypeID %v, got %s ", id, err.Error()),
+		)
+	}
+	d.SetId("")
+	return diags
+}
+
+// resourceCredentialTypeCustomizeDiff fails the plan when an injector
+// template references a variable that is no input, or required_inputs names
+// an undeclared input. Values unknown until apply aren't checked.
+func resourceCredentialTypeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("input") || !d.NewValueKnown("injectors") || !d.NewValueKnown("required_inputs") {
+		return nil
+	}
+	inputs := map[string]bool{}
+	for _, item := range d.Get("input").([]interface{}) {
+		if item == nil {
+			continue
+		}
+		id := item.(map[string]interface{})["id"].(string)
+		if id == "" {
+			return nil
+		}
+		inputs[id] = true
+	}
+
+	for _, id := range d.Get("required_inputs").([]interface{}) {
+		if id != nil && !inputs[id.(string)] {
+			return fmt.Errorf("required_inputs names %q, which is no input", id)
+		}
+	}
+
+	injectors := d.Get("injectors").([]interface{})
+	if len(injectors) == 0 || injectors[0] == nil {
+		return nil
+	}
+	return checkCredentialTypeInjectors(inputs, injectors[0].(map[string]interface{}))
+}
+
+// checkCredentialTypeInjectors fails when a template of the injectors block
+// references a variable that is neither one of inputs nor a builtin.
+func checkCredentialTypeInjectors(inputs map[string]bool, injectors map[string]interface{}) error {
+	for _, kind := range []string{"env", "extra_vars", "file"} {
+		templates, _ := injectors[kind].(map[string]interface{})
+		keys := make([]string, 0, len(templates))
+		for key := range templates {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			for _, name := range injectorTemplateVariables(templates[key].(string)) {
+				if !inputs[name] && !injectorBuiltins[name] {
+					return fmt.Errorf("injector %s.%s references %q, which is no input of the credential type", kind, key, name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// injectorTemplateVariables returns the variables the expressions and
+// statements of template read. Attribute, filter, test and keyword argument
+// names are skipped, as are the variables the template sets itself with for
+// and set.
+func injectorTemplateVariables(template string) []string {
+	var tags [][]string
+	locals := map[string]bool{}
+	for _, match := range injectorTagPattern.FindAllStringSubmatch(template, -1) {
+		body := match[1]
+		if match[1] == "" {
+			body = match[2]
+		}
+		tokens := injectorTokenPattern.FindAllString(strings.Trim(body, "-+"), -1)
+		tags = append(tags, tokens)
+		if len(tokens) == 0 {
+			continue
+		}
+		switch tokens[0] {
+		case "for":
+			for _, token := range tokens[1:] {
+				if token == "in" {
+					break
+				}
+				locals[token] = true
+			}
+		case "set":
+			if len(tokens) > 1 {
+				locals[tokens[1]] = true
+			}
+		}
+	}
+
+	var names []string
+	seen := map[string]bool{}
+	for _, tokens := range tags {
+		for i, token := range tokens {
+			if !isInjectorName(token) || injectorJinjaNames[token] || locals[token] || seen[token] {
+				continue
+			}
+			if i > 0 && (tokens[i-1] == "." || tokens[i-1] == "|" || tokens[i-1] == "is" ||
+				(tokens[i-1] == "not" && i > 1 && tokens[i-2] == "is")) {
+				// Attributes, filters and tests.
+				continue
+			}
+			if i+1 < len(tokens) && tokens[i+1] == "=" && i > 0 && (tokens[i-1] == "(" || tokens[i-1] == ",") {
+				// Keyword arguments.
+				continue
+			}
+			seen[token] = true
+			names = append(names, token)
+		}
+	}
+	return names
+}
+
+func isInjectorName(token string) bool {
+	c := token[0]
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func expandCredentialType(d *schema.ResourceData) map[string]interface{} {
+	inputs := credentialTypeInputs{Fields: []credentialTypeField{}}
+	for _, item := range d.Get("input").([]interface{}) {
+		field := item.(map[string]interface{})
+		input := credentialTypeField{
+			ID:        field["id"].(string),
+			Label:     field["label"].(string),
+			Type:      field["type"].(string),
+			Secret:    field["secret"].(bool),
+			Multiline: field["multiline"].(bool),
+			HelpText:  field["help_text"].(string),
+			Format:    field["format"].(string),
+		}
+		for _, choice := range field["choices"].([]interface{}) {
+			input.Choices = append(input.Choices, choice.(string))
+		}
+		inputs.Fields = append(inputs.Fields, input)
+	}
+	for _, id := range d.Get("required_inputs").([]interface{}) {
+		inputs.Required = append(inputs.Required, id.(string))
+	}
+
+	injectors := credentialTypeInjectors{}
+	if items := d.Get("injectors").([]interface{}); len(items) > 0 && items[0] != nil {
+		item := items[0].(map[string]interface{})
+		injectors.Env = expandStringMap(item["env"])
+		injectors.File = expandStringMap(item["file"])
+		if extraVars := expandStringMap(item["extra_vars"]); extraVars != nil {
+			injectors.ExtraVars = map[string]interface{}{}
+			for key, value := range extraVars {
+				injectors.ExtraVars[key] = value
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"kind":        d.Get("kind").(string),
+		"inputs":      inputs,
+		"injectors":   injectors,
+	}
+}
+
+func expandStringMap(v interface{}) map[string]string {
+	items, _ := v.(map[string]interface{})
+	if len(items) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(items))
+	for key, value := range items {
+		result[key] = value.(string)
+	}
+	return result
+}
+
+// flattenCredentialTypeFields returns the input blocks of t.
+func flattenCredentialTypeFields(t *credentialType) []interface{} {
+	fields := make([]interface{}, 0, len(t.Inputs.Fields))
+	for _, field := range t.Inputs.Fields {
+		fieldType := field.Type
+		if fieldType == "" {
+			fieldType = "string"
+		}
+		choices := make([]interface{}, 0, len(field.Choices))
+		for _, choice := range field.Choices {
+			choices = append(choices, choice)
+		}
+		fields = append(fields, map[string]interface{}{
+			"id":        field.ID,
+			"label":     field.Label,
+			"type":      fieldType,
+			"secret":    field.Secret,
+			"multiline": field.Multiline,
+			"help_text": field.HelpText,
+			"format":    field.Format,
+			"choices":   choices,
+		})
+	}
+	return fields
+}
+
+func setCredentialTypeResourceData(d *schema.ResourceData, r *credentialType) *schema.ResourceData {
+	d.Set("name", r.Name)
+	d.Set("description", r.Description)
+	d.Set("kind", r.Kind)
+	d.Set("namespace", r.Namespace)
+	d.Set("input", flattenCredentialTypeFields(r))
+	d.Set("required_inputs", r.Inputs.Required)
+
+	// An empty injectors block of the configuration is kept, AWX returns it as
+	// empty maps.
+	injectors := []interface{}{}
+	if len(r.Injectors.Env) > 0 || len(r.Injectors.ExtraVars) > 0 || len(r.Injectors.File) > 0 ||
+		len(d.Get("injectors").([]interface{})) > 0 {
+		injectors = append(injectors, map[string]interface{}{
+			"env":        r.Injectors.Env,
+			"extra_vars": flattenCredentialInputs(r.Injectors.ExtraVars, nil),
+			"file":       r.Injectors.File,
+		})
+	}
+	d.Set("injectors", injectors)
+	return d
+}
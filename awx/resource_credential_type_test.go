@@ -0,0 +1,110 @@
+package awx
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestInjectorTemplateVariables(t *testing.T) {
+	cases := []struct {
+		template string
+		want     []string
+	}{
+		{"{{ url }}", []string{"url"}},
+		{"{{- url -}}", []string{"url"}},
+		{"plain text", nil},
+		{"{{ url | lower }}", []string{"url"}},
+		{"{{ url | default(token) }}", []string{"url", "token"}},
+		{"{{ url | replace('token', \"secret\") }}", []string{"url"}},
+		{"{{ 'x' ~ token }}", []string{"token"}},
+		{"{% if token %}{{ token }}{% endif %}", []string{"token"}},
+		{"{% if token is defined and token is not none %}x{% else %}y{% endif %}", []string{"token"}},
+		{"{% for host in hosts %}{{ host }}{{ loop.index }}{% endfor %}", []string{"hosts"}},
+		{"{% for key, value in settings.items() %}{{ key }}={{ value }}{% endfor %}", []string{"settings"}},
+		{"{% set base = url ~ '/api' %}{{ base }}", []string{"url"}},
+		{"{{ tower.filename }} {{ tower.filename.kubeconfig }}", []string{"tower"}},
+		{"{{ tower['filename'] }}", []string{"tower"}},
+		{"{{ url if secure else insecure_url }}", []string{"url", "secure", "insecure_url"}},
+		{"{{ range(3) | join(sep=separator) }}", []string{"separator"}},
+		{"{# token #}{{ url }}", []string{"url"}},
+		{"{{ true }} {{ none }} {{ 42 }}", nil},
+	}
+	for _, c := range cases {
+		got := injectorTemplateVariables(c.template)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("injectorTemplateVariables(%q) = %q, want %q", c.template, got, c.want)
+		}
+	}
+}
+
+func TestCheckCredentialTypeInjectors(t *testing.T) {
+	inputs := map[string]bool{"url": true, "token": true}
+	cases := []struct {
+		name      string
+		injectors map[string]interface{}
+		wantErr   bool
+	}{
+		{"no templates", map[string]interface{}{}, false},
+		{"inputs", map[string]interface{}{"env": map[string]interface{}{"URL": "{{ url }}", "TOKEN": "{{ token }}"}}, false},
+		{"builtins", map[string]interface{}{"extra_vars": map[string]interface{}{"config": "{{ tower.filename }}", "other": "{{ awx.filename }}"}}, false},
+		{"undeclared", map[string]interface{}{"env": map[string]interface{}{"URL": "{{ uri }}"}}, true},
+		{"undeclared filter argument", map[string]interface{}{"env": map[string]interface{}{"URL": "{{ url | default(fallback) }}"}}, true},
+		{"undeclared concatenation", map[string]interface{}{"extra_vars": map[string]interface{}{"url": "{{ 'https://' ~ host }}"}}, true},
+		{"undeclared in if", map[string]interface{}{"file": map[string]interface{}{"template": "{% if password %}x{% endif %}"}}, true},
+		{"undeclared in for", map[string]interface{}{"file": map[string]interface{}{"template": "{% for h in hosts %}{{ h }}{% endfor %}"}}, true},
+		{"declared in if", map[string]interface{}{"file": map[string]interface{}{"template": "{% if token %}{{ token }}{% else %}{{ url }}{% endif %}"}}, false},
+	}
+	for _, c := range cases {
+		err := checkCredentialTypeInjectors(inputs, c.injectors)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: checkCredentialTypeInjectors() error = %v, want error %t", c.name, err, c.wantErr)
+		}
+	}
+}
+
+func TestSetCredentialTypeResourceDataKeepsEmptyInjectors(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceCredentialType().Schema, map[string]interface{}{
+		"name":      "custom",
+		"kind":      "cloud",
+		"injectors": []interface{}{map[string]interface{}{}},
+	})
+	setCredentialTypeResourceData(d, &credentialType{Name: "custom", Kind: "cloud"})
+	if got := len(d.Get("injectors").([]interface{})); got != 1 {
+		t.Errorf("configured empty injectors block read back as %d blocks, want 1", got)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceCredentialType().Schema, map[string]interface{}{
+		"name": "custom",
+		"kind": "cloud",
+	})
+	setCredentialTypeResourceData(d, &credentialType{Name: "custom", Kind: "cloud"})
+	if got := len(d.Get("injectors").([]interface{})); got != 0 {
+		t.Errorf("absent injectors block read back as %d blocks, want 0", got)
+	}
+}
+
+func TestSetCredentialTypeResourceDataEncodesExtraVars(t *testing.T) {
+	var r credentialType
+	err := json.Unmarshal([]byte(`{"name": "custom", "kind": "cloud", "injectors": {"extra_vars": {
+		"url": "{{ url }}", "port": 8443, "verify": false, "tags": ["a", "b"], "settings": {"retries": 3}
+	}}}`), &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := schema.TestResourceDataRaw(t, resourceCredentialType().Schema, map[string]interface{}{"name": "custom", "kind": "cloud"})
+	setCredentialTypeResourceData(d, &r)
+
+	want := map[string]interface{}{
+		"url":      "{{ url }}",
+		"port":     "8443",
+		"verify":   "false",
+		"tags":     `["a","b"]`,
+		"settings": `{"retries":3}`,
+	}
+	if got := d.Get("injectors.0.extra_vars"); !reflect.DeepEqual(got, want) {
+		t.Errorf("extra_vars read back as %v, want %v", got, want)
+	}
+}
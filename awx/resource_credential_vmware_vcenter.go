@@ -0,0 +1,184 @@
+/*
+Manages a VMware vCenter credential for vCenter inventory sources and
+playbooks that talk to vCenter.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_credential_vmware_vcenter" "vcenter" {
+	  name            = "vCenter"
+	  organisation_id = awx_organization.default.id
+	  host            = "vcenter.example.com"
+	  username        = "svc-awx@vsphere.local"
+	  password        = var.vcenter_password
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+func resourceCredentialVMwareVCenter() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCredentialVMwareVCenterCreate,
+		ReadContext:   resourceCredentialVMwareVCenterRead,
+		UpdateContext: resourceCredentialVMwareVCenterUpdate,
+		DeleteContext: CredentialsServiceDeleteByID,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"organisation_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"host": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringDoesNotContainAny("/"),
+				Description:  "Hostname or IP address of the vCenter, without scheme",
+			},
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Username of the vCenter user",
+			},
+			"password": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Password of the vCenter user",
+			},
+		},
+	}
+}
+
+func expandCredentialVMwareVCenterInputs(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"host":     d.Get("host").(string),
+		"username": d.Get("username").(string),
+		"password": d.Get("password").(string),
+	}
+}
+
+func resourceCredentialVMwareVCenterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	vcenterType, diags := managedCredentialType(m, "vmware")
+	if diags.HasError() {
+		return diags
+	}
+	inputs := expandCredentialVMwareVCenterInputs(d)
+	if err := checkCredentialInputs(vcenterType, inputs); err != nil {
+		return buildDiagnosticsMessage("Unable to create new credentials", "Unable to create new credentials: %s", err.Error())
+	}
+	newCredential := map[string]interface{}{
+		"name":            d.Get("name").(string),
+		"description":     d.Get("description").(string),
+		"organization":    d.Get("organisation_id").(int),
+		"credential_type": vcenterType.ID,
+		"inputs":          inputs,
+	}
+
+	client := m.(*awx.AWX)
+	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
+	if err != nil {
+		return buildDiagAPIFail(
+			"Unable to create new credentials", err, credentialFieldAttributes,
+			"Unable to create new credentials: %s", err.Error(),
+		)
+	}
+
+	d.SetId(strconv.Itoa(cred.ID))
+	resourceCredentialVMwareVCenterRead(ctx, d, m)
+
+	return diags
+}
+
+func resourceCredentialVMwareVCenterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*awx.AWX)
+	id, _ := strconv.Atoi(d.Id())
+	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
+	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "credentials", id)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to fetch credentials",
+			Detail:   fmt.Sprintf("Unable to credentials with id %d: %s", id, err.Error()),
+		})
+		return diags
+	}
+
+	d.Set("name", cred.Name)
+	d.Set("description", cred.Description)
+	d.Set("host", cred.Inputs["host"])
+	d.Set("username", cred.Inputs["username"])
+	setCredentialSecret(d, "password", cred.Inputs["password"])
+	d.Set("organisation_id", cred.OrganizationID)
+
+	return diags
+}
+
+func resourceCredentialVMwareVCenterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	keys := []string{
+		"name",
+		"description",
+		"host",
+		"username",
+		"password",
+		"organisation_id",
+	}
+
+	if d.HasChanges(keys...) {
+		var err error
+
+		id, _ := strconv.Atoi(d.Id())
+		vcenterType, diags := managedCredentialType(m, "vmware")
+		if diags.HasError() {
+			return diags
+		}
+		inputs := expandCredentialVMwareVCenterInputs(d)
+		if err := checkCredentialInputs(vcenterType, inputs); err != nil {
+			return buildDiagnosticsMessage("Unable to update existing credentials", "Unable to update existing credentials with id %d: %s", id, err.Error())
+		}
+		updatedCredential := map[string]interface{}{
+			"name":            d.Get("name").(string),
+			"description":     d.Get("description").(string),
+			"organization":    d.Get("organisation_id").(int),
+			"credential_type": vcenterType.ID,
+			"inputs":          inputs,
+		}
+
+		client := m.(*awx.AWX)
+		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
+		if err != nil {
+			return buildDiagAPIFail(
+				"Unable to update existing credentials", err, credentialFieldAttributes,
+				"Unable to update existing credentials with id %d: %s", id, err.Error(),
+			)
+		}
+	}
+
+	return resourceCredentialVMwareVCenterRead(ctx, d, m)
+}
@@ -169,6 +169,9 @@ func resourceHostRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	}
 	res, err := awxService.GetHostByID(id, make(map[string]string))
 	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, diagElementHostTitle, id)
+		}
 		return buildDiagNotFoundFail(diagElementHostTitle, id, err)
 	}
 	d = setHostResourceData(d, res)
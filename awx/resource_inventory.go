@@ -130,6 +130,9 @@ func resourceInventoryRead(ctx context.Context, d *schema.ResourceData, m interf
 	}
 	r, err := awxService.GetInventory(id, map[string]string{})
 	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, diagElementInventoryTitle, id)
+		}
 		return buildDiagNotFoundFail(diagElementInventoryTitle, id, err)
 	}
 	d = setInventoryResourceData(d, r)
@@ -128,6 +128,9 @@ func resourceInventoryGroupRead(ctx context.Context, d *schema.ResourceData, m i
 
 	res, err := awxService.GetGroupByID(id, make(map[string]string))
 	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, diagElementInventoryGroupTitle, id)
+		}
 		return buildDiagNotFoundFail(diagElementInventoryGroupTitle, id, err)
 	}
 	d = setInventoryGroupResourceData(d, res)
@@ -164,6 +164,9 @@ func resourceInventorySourceRead(ctx context.Context, d *schema.ResourceData, m
 	}
 	res, err := awxService.GetInventorySourceByID(id, make(map[string]string))
 	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, diagElementInventorySourceTitle, id)
+		}
 		return buildDiagNotFoundFail(diagElementInventorySourceTitle, id, err)
 	}
 	d = setInventorySourceResourceData(d, res)
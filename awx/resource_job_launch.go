@@ -0,0 +1,249 @@
+/*
+Launches a job template. The job is launched again when one of the arguments or
+the triggers change, destroying the resource keeps the job in AWX.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_job_launch" "bootstrap" {
+	  job_template_id     = awx_job_template.bootstrap.id
+	  limit               = awx_host.web.name
+	  extra_vars          = jsonencode({ "role" = "web" })
+	  wait_for_completion = true
+	  fail_on_job_failure = true
+
+	  triggers = {
+	    host_id = awx_host.web.id
+	  }
+
+	  timeouts {
+	    create = "30m"
+	  }
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+// jobLaunchFieldAttributes maps the AWX launch errors to the prompts of the resource.
+var jobLaunchFieldAttributes = map[string]string{
+	"credentials":               "credential_ids",
+	"variables_needed_to_start": "extra_vars",
+}
+
+func resourceJobLaunch() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceJobLaunchCreate,
+		ReadContext:   resourceJobLaunchRead,
+		UpdateContext: resourceJobLaunchUpdate,
+		DeleteContext: resourceJobLaunchDelete,
+
+		Schema: unifiedJobSchema("job", map[string]*schema.Schema{
+			"job_template_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Numeric ID of the job template to launch",
+			},
+			"extra_vars": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				StateFunc:   normalizeJsonYaml,
+				Description: "Extra variables in JSON or YAML, the job template has to prompt for variables or define a survey",
+			},
+			"inventory_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Inventory applied as a prompt, assuming the job template prompts for inventory",
+			},
+			"credential_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Credentials applied as a prompt, assuming the job template prompts for credentials",
+			},
+			"limit": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Host pattern applied as a prompt, assuming the job template prompts for limit",
+			},
+			"job_tags": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Tags applied as a prompt, assuming the job template prompts for tags",
+			},
+			"skip_tags": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Skipped tags applied as a prompt, assuming the job template prompts for skip tags",
+			},
+			"verbosity": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(0, 5),
+				Description:  "One of 0,1,2,3,4,5, applied as a prompt, assuming the job template prompts for verbosity",
+			},
+			"diff_mode": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Diff mode applied as a prompt, assuming the job template prompts for diff mode",
+			},
+			"scm_branch": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Branch applied as a prompt, assuming the job template prompts for the SCM branch",
+			},
+			"triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values, the job is launched again when it changes",
+			},
+			"wait_for_completion": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait until the job is finished, bounded by the create timeout",
+			},
+			"fail_on_job_failure": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Fail the apply when the job doesn't succeed, only used with wait_for_completion",
+			},
+			"job_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Numeric ID of the launched job",
+			},
+		}),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+	}
+}
+
+func resourceJobLaunchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*awx.AWX)
+	awxService := client.JobTemplateService
+	jobTemplateID := d.Get("job_template_id").(int)
+
+	data := map[string]interface{}{}
+	if v, ok := d.GetOk("extra_vars"); ok {
+		data["extra_vars"] = v.(string)
+	}
+	if v, ok := d.GetOk("inventory_id"); ok {
+		data["inventory"] = v.(int)
+	}
+	if v, ok := d.GetOk("credential_ids"); ok {
+		data["credentials"] = v.([]interface{})
+	}
+	for _, key := range []string{"limit", "job_tags", "skip_tags", "scm_branch"} {
+		if v, ok := d.GetOk(key); ok {
+			data[key] = v.(string)
+		}
+	}
+	if isConfigured(d, "verbosity") {
+		data["verbosity"] = d.Get("verbosity").(int)
+	}
+	if isConfigured(d, "diff_mode") {
+		data["diff_mode"] = d.Get("diff_mode").(bool)
+	}
+
+	result, err := awxService.Launch(jobTemplateID, data, map[string]string{})
+	if err != nil {
+		log.Printf("Fail to launch JobTemplate %v", err)
+		return buildDiagAPIFail(
+			"Unable to launch JobTemplate", err, jobLaunchFieldAttributes,
+			"JobTemplate with id %d faild to launch %s", jobTemplateID, err.Error(),
+		)
+	}
+	d.SetId(strconv.Itoa(result.Job))
+	diags = append(diags, buildDiagIgnoredLaunchFields("JobTemplate", jobTemplateID, result.IgnoredFields)...)
+
+	if !d.Get("wait_for_completion").(bool) {
+		return append(diags, resourceJobLaunchRead(ctx, d, m)...)
+	}
+	return append(diags, waitForLaunchedJob(ctx, d, m, launchedJob{
+		kind:     "Job",
+		endpoint: jobsAPIEndpoint,
+		id:       result.Job,
+		origin:   fmt.Sprintf("of JobTemplate with id %d", jobTemplateID),
+	}, resourceJobLaunchRead)...)
+}
+
+func resourceJobLaunchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only wait_for_completion and fail_on_job_failure can change without
+	// launching a new job.
+	return resourceJobLaunchRead(ctx, d, m)
+}
+
+func resourceJobLaunchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read Job", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	res, diags := getLaunchedJob(m, "job", jobsAPIEndpoint, id)
+	if res == nil {
+		return diags
+	}
+	d.Set("job_id", res.ID)
+	setUnifiedJobResourceData(d, res)
+	return nil
+}
+
+func resourceJobLaunchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Jobs are part of the AWX history, only the state is cleared.
+	d.SetId("")
+	return nil
+}
+
+// buildDiagIgnoredLaunchFields warns about prompts AWX ignored because the
+// template doesn't ask for them on launch.
+func buildDiagIgnoredLaunchFields(tfElement string, id int, ignoredFields map[string]string) diag.Diagnostics {
+	if len(ignoredFields) == 0 {
+		return nil
+	}
+	fields := make([]string, 0, len(ignoredFields))
+	for field := range ignoredFields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Launch prompts ignored",
+		Detail: fmt.Sprintf(
+			"%s with id %d ignored %s, enable prompting on launch for them in the template",
+			tfElement, id, strings.Join(fields, ", "),
+		),
+	}}
+}
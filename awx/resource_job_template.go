@@ -135,6 +135,11 @@ func resourceJobTemplate() *schema.Resource {
 				Optional: true,
 				Default:  "",
 			},
+			"callback_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Provisioning callback URL of the job template, empty without host_config_key",
+			},
 			"ask_diff_mode_on_launch": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -175,6 +180,7 @@ func resourceJobTemplate() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"survey": surveySchema,
 			"become_enabled": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -232,7 +238,6 @@ func resourceJobTemplate() *schema.Resource {
 }
 
 func resourceJobTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
 	awxService := client.JobTemplateService
 
@@ -273,15 +278,21 @@ func resourceJobTemplateCreate(ctx context.Context, d *schema.ResourceData, m in
 	}, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Template %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create JobTemplate",
-			Detail:   fmt.Sprintf("JobTemplate with name %s in the project id %d, faild to create %s", d.Get("name").(string), d.Get("project_id").(int), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail(
+			"Unable to create JobTemplate", err, nil,
+			"JobTemplate with name %s in the project id %d, faild to create %s", d.Get("name").(string), d.Get("project_id").(int), err.Error(),
+		)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
+	if _, ok := d.GetOk("survey"); ok {
+		if err := updateSurveySpec(d, m, jobTemplatesAPIEndpoint, result.ID); err != nil {
+			return buildDiagAPIFail(
+				"Unable to create JobTemplate survey", err, surveyFieldAttributes,
+				"Survey of JobTemplate with id %d faild to create %s", result.ID, err.Error(),
+			)
+		}
+	}
 	return resourceJobTemplateRead(ctx, d, m)
 }
 
@@ -336,12 +347,18 @@ func resourceJobTemplateUpdate(ctx context.Context, d *schema.ResourceData, m in
 		"job_slice_count":          d.Get("job_slice_count").(int),
 	}, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update JobTemplate",
-			Detail:   fmt.Sprintf("JobTemplate with name %s in the project id %d faild to update %s", d.Get("name").(string), d.Get("project_id").(int), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail(
+			"Unable to update JobTemplate", err, nil,
+			"JobTemplate with name %s in the project id %d faild to update %s", d.Get("name").(string), d.Get("project_id").(int), err.Error(),
+		)
+	}
+	if d.HasChange("survey") {
+		if err := updateSurveySpec(d, m, jobTemplatesAPIEndpoint, id); err != nil {
+			return buildDiagAPIFail(
+				"Unable to update JobTemplate survey", err, surveyFieldAttributes,
+				"Survey of JobTemplate with id %d faild to update %s", id, err.Error(),
+			)
+		}
 	}
 
 	return resourceJobTemplateRead(ctx, d, m)
@@ -358,10 +375,17 @@ func resourceJobTemplateRead(ctx context.Context, d *schema.ResourceData, m inte
 
 	res, err := awxService.GetJobTemplateByID(id, make(map[string]string))
 	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "job template", id)
+		}
 		return buildDiagNotFoundFail("job template", id, err)
 
 	}
 	d = setJobTemplateResourceData(d, res)
+	d.Set("callback_url", jobTemplateCallbackURL(m, res))
+	if err := readSurveySpec(d, m, jobTemplatesAPIEndpoint, id); err != nil {
+		return buildDiagNotFoundFail("job template survey", id, err)
+	}
 	return nil
 }
 
@@ -398,3 +422,16 @@ func setJobTemplateResourceData(d *schema.ResourceData, r *awx.JobTemplate) *sch
 	d.SetId(strconv.Itoa(r.ID))
 	return d
 }
+
+// jobTemplateCallbackURL returns the absolute provisioning callback URL, AWX
+// only offers the callback with a host config key.
+func jobTemplateCallbackURL(m interface{}, r *awx.JobTemplate) string {
+	if r.HostConfigKey == "" {
+		return ""
+	}
+	requester, err := requesterFor(m)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s%s%d/callback/", strings.TrimSuffix(requester.Base, "/"), jobTemplatesAPIEndpoint, r.ID)
+}
@@ -0,0 +1,162 @@
+/*
+Launches a job template through its provisioning callback on behalf of a host
+and waits until the job is finished. The job is launched again when one of the
+arguments or the triggers change, destroying the resource keeps the job in AWX.
+
+AWX limits the callback job to the inventory host matching the address of the
+caller. Set host when Terraform runs elsewhere, it is sent as X-Forwarded-For
+and requires AWX to trust that header in its REMOTE_HOST_HEADERS setting.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_job_template_callback" "web" {
+	  job_template_id = awx_job_template.bootstrap.id
+	  host_config_key = awx_job_template.bootstrap.host_config_key
+	  host            = aws_instance.web.private_ip
+	  extra_vars      = jsonencode({ "role" = "web" })
+
+	  triggers = {
+	    instance_id = aws_instance.web.id
+	  }
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// jobLocationPattern matches the job id in the Location header of a callback.
+var jobLocationPattern = regexp.MustCompile(`/jobs/(\d+)/?$`)
+
+func resourceJobTemplateCallback() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceJobTemplateCallbackCreate,
+		ReadContext:   resourceJobTemplateCallbackRead,
+		UpdateContext: resourceJobTemplateCallbackUpdate,
+		DeleteContext: resourceJobTemplateCallbackDelete,
+
+		Schema: unifiedJobSchema("job", map[string]*schema.Schema{
+			"job_template_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Numeric ID of the job template to launch",
+			},
+			"host_config_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				Description: "Host config key of the job template",
+			},
+			"host": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Address of the host the job runs for, sent as X-Forwarded-For",
+			},
+			"extra_vars": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				StateFunc:   normalizeJsonYaml,
+				Description: "Extra variables in JSON or YAML, the job template has to prompt for variables or define a survey",
+			},
+			"triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values, the job is launched again when it changes",
+			},
+			"job_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Numeric ID of the launched job",
+			},
+		}),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+	}
+}
+
+func resourceJobTemplateCallbackCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	jobTemplateID := d.Get("job_template_id").(int)
+
+	data := map[string]interface{}{
+		"host_config_key": d.Get("host_config_key").(string),
+	}
+	if v, ok := d.GetOk("extra_vars"); ok {
+		data["extra_vars"] = v.(string)
+	}
+	headers := map[string]string{}
+	if v, ok := d.GetOk("host"); ok {
+		headers["X-Forwarded-For"] = v.(string)
+	}
+
+	location, err := apiPostLocation(m, fmt.Sprintf("%s%d/callback/", jobTemplatesAPIEndpoint, jobTemplateID), data, headers)
+	if err != nil {
+		log.Printf("Fail to call back JobTemplate %v", err)
+		return buildDiagAPIFail(
+			"Unable to call back JobTemplate", err, jobLaunchFieldAttributes,
+			"JobTemplate with id %d faild to launch through its callback %s", jobTemplateID, err.Error(),
+		)
+	}
+	match := jobLocationPattern.FindStringSubmatch(location)
+	if match == nil {
+		return buildDiagnosticsMessage(
+			"Unable to call back JobTemplate",
+			"JobTemplate with id %d didn't return the launched job, Location: %q", jobTemplateID, location,
+		)
+	}
+	jobID, _ := strconv.Atoi(match[1])
+	d.SetId(strconv.Itoa(jobID))
+
+	return waitForLaunchedJob(ctx, d, m, launchedJob{
+		kind:     "Job",
+		endpoint: jobsAPIEndpoint,
+		id:       jobID,
+		origin:   fmt.Sprintf("of JobTemplate with id %d", jobTemplateID),
+	}, resourceJobTemplateCallbackRead)
+}
+
+func resourceJobTemplateCallbackUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only fail_on_job_failure can change without launching a new job.
+	return resourceJobTemplateCallbackRead(ctx, d, m)
+}
+
+func resourceJobTemplateCallbackRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read Job", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	res, diags := getLaunchedJob(m, "job", jobsAPIEndpoint, id)
+	if res == nil {
+		return diags
+	}
+	d.Set("job_id", res.ID)
+	setUnifiedJobResourceData(d, res)
+	return nil
+}
+
+func resourceJobTemplateCallbackDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Jobs are part of the AWX history, only the state is cleared.
+	d.SetId("")
+	return nil
+}
@@ -109,17 +109,20 @@ func resourceJobTemplateCredentialsRead(ctx context.Context, d *schema.ResourceD
 	jobTemplateId, err := strconv.Atoi(parts[0])
 	if err != nil {
 		return buildDiagnosticsMessage(
-			fmt.Sprintf("%s, state ID not converted", d.Id()), "Value in State %s is unparseable, %s", d.Id())
+			fmt.Sprintf("%s, state ID not converted", d.Id()), "Value in State %s is unparseable, %s", d.Id(), err.Error())
 	}
 
 	credentialId, err := strconv.Atoi(parts[1])
 	if err != nil {
 		return buildDiagnosticsMessage(
-			fmt.Sprintf("%s, state ID not converted", d.Id()), "Value in State %s is unparseable, %s", d.Id())
+			fmt.Sprintf("%s, state ID not converted", d.Id()), "Value in State %s is unparseable, %s", d.Id(), err.Error())
 	}
 
 	_, err = awxService.GetJobTemplateByID(jobTemplateId, make(map[string]string))
 	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "job template", jobTemplateId)
+		}
 		return buildDiagNotFoundFail("job template", jobTemplateId, err)
 	}
 
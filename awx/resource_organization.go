@@ -66,7 +66,6 @@ func resourceOrganization() *schema.Resource {
 }
 
 func resourceOrganizationsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
 	awxService := client.OrganizationsService
 
@@ -78,12 +77,10 @@ func resourceOrganizationsCreate(ctx context.Context, d *schema.ResourceData, m
 	}, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Template %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create Organizations",
-			Detail:   fmt.Sprintf("Organizations with name %s in the project id %d, faild to create %s", d.Get("name").(string), d.Get("project_id").(int), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail(
+			"Unable to create Organizations", err, nil,
+			"Organizations with name %s faild to create %s", d.Get("name").(string), err.Error(),
+		)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -113,12 +110,10 @@ func resourceOrganizationsUpdate(ctx context.Context, d *schema.ResourceData, m
 		"custom_virtualenv": d.Get("description").(string),
 	}, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update Organizations",
-			Detail:   fmt.Sprintf("Organizations with name %s faild to update %s", d.Get("name").(string), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail(
+			"Unable to update Organizations", err, nil,
+			"Organizations with name %s faild to update %s", d.Get("name").(string), err.Error(),
+		)
 	}
 
 	return resourceOrganizationsRead(ctx, d, m)
@@ -135,6 +130,9 @@ func resourceOrganizationsRead(ctx context.Context, d *schema.ResourceData, m in
 
 	res, err := awxService.GetOrganizationsByID(id, make(map[string]string))
 	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "Organization", id)
+		}
 		return buildDiagNotFoundFail("Organization", id, err)
 
 	}
@@ -121,6 +121,12 @@ func resourceProject() *schema.Resource {
 	}
 }
 
+// projectFieldAttributes maps the AWX fields of a project that are named
+// differently in the resource.
+var projectFieldAttributes = map[string]string{
+	"credential": "scm_credential_id",
+}
+
 func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*awx.AWX)
 	awxService := client.ProjectService
@@ -158,7 +164,7 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 		"scm_update_cache_timeout": d.Get("scm_update_cache_timeout").(int),
 	}, map[string]string{})
 	if err != nil {
-		return buildDiagnosticsMessage("Create: Project not created", "Project with name %s  in the Organisation ID %v not created, %s", projectName, orgID, err.Error())
+		return buildDiagAPIFail("Create: Project not created", err, projectFieldAttributes, "Project with name %s  in the Organisation ID %v not created, %s", projectName, orgID, err.Error())
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -192,7 +198,7 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		"scm_update_cache_timeout": d.Get("scm_update_cache_timeout").(int),
 	}, map[string]string{})
 	if err != nil {
-		return buildDiagnosticsMessage("Update: Fail To Update Project", "Fail to get Project with ID %v, got %s", id, err.Error())
+		return buildDiagAPIFail("Update: Fail To Update Project", err, projectFieldAttributes, "Fail to get Project with ID %v, got %s", id, err.Error())
 	}
 	return resourceProjectRead(ctx, d, m)
 }
@@ -209,6 +215,9 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interfac
 
 	res, err := awxService.GetProjectById(id, make(map[string]string))
 	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "project", id)
+		}
 		return buildDiagNotFoundFail("project", id, err)
 	}
 	d = setProjectResourceData(d, res)
@@ -0,0 +1,131 @@
+/*
+Launches a system job template and waits until the system job is finished,
+optionally with the days of history to keep. The system job is launched again
+when one of the arguments or the triggers change, destroying the resource keeps
+the system job in AWX.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_system_job_launch" "purge_activity_stream" {
+	  system_job_template_id = data.awx_system_job_template.cleanup_activitystream.id
+	  days                   = 7
+
+	  triggers = {
+	    purge = var.purge_request
+	  }
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const systemJobsAPIEndpoint = "/api/v2/system_jobs/"
+
+type systemJobLaunch struct {
+	SystemJob int `json:"system_job"`
+}
+
+func resourceSystemJobLaunch() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSystemJobLaunchCreate,
+		ReadContext:   resourceSystemJobLaunchRead,
+		UpdateContext: resourceSystemJobLaunchUpdate,
+		DeleteContext: resourceSystemJobLaunchDelete,
+
+		Schema: unifiedJobSchema("system job", map[string]*schema.Schema{
+			"system_job_template_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Numeric ID of the system job template to launch",
+			},
+			"days": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Days of history to keep, overrides the default of the system job template",
+			},
+			"triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values, the system job is launched again when it changes",
+			},
+		}),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+	}
+}
+
+func resourceSystemJobLaunchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	systemJobTemplateID := d.Get("system_job_template_id").(int)
+
+	data := map[string]interface{}{}
+	if isConfigured(d, "days") {
+		data["extra_vars"] = map[string]interface{}{"days": d.Get("days").(int)}
+	}
+
+	result := new(systemJobLaunch)
+	err := apiPost(m, fmt.Sprintf("%s%d/launch/", systemJobTemplatesAPIEndpoint, systemJobTemplateID), data, result)
+	if err == nil && result.SystemJob == 0 {
+		err = fmt.Errorf("invalid system job id 0")
+	}
+	if err != nil {
+		log.Printf("Fail to launch SystemJobTemplate %v", err)
+		return buildDiagAPIFail(
+			"Unable to launch SystemJobTemplate", err, map[string]string{"extra_vars": "days"},
+			"SystemJobTemplate with id %d faild to launch %s", systemJobTemplateID, err.Error(),
+		)
+	}
+	d.SetId(strconv.Itoa(result.SystemJob))
+
+	return waitForLaunchedJob(ctx, d, m, launchedJob{
+		kind:     "SystemJob",
+		endpoint: systemJobsAPIEndpoint,
+		id:       result.SystemJob,
+		origin:   fmt.Sprintf("of SystemJobTemplate with id %d", systemJobTemplateID),
+	}, resourceSystemJobLaunchRead)
+}
+
+func resourceSystemJobLaunchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only fail_on_job_failure can change without launching a new system job.
+	return resourceSystemJobLaunchRead(ctx, d, m)
+}
+
+func resourceSystemJobLaunchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read SystemJob", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	res, diags := getLaunchedJob(m, "system job", systemJobsAPIEndpoint, id)
+	if res == nil {
+		return diags
+	}
+	setUnifiedJobResourceData(d, res)
+	return nil
+}
+
+func resourceSystemJobLaunchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// System jobs are part of the AWX history, only the state is cleared.
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,212 @@
+/*
+Manages a schedule of a system job template, with the extra data its system
+jobs run with, like the days of history the cleanup keeps.
+
+# Example Usage
+
+```hcl
+
+	data "awx_system_job_template" "cleanup_jobs" {
+	  job_type = "cleanup_jobs"
+	}
+
+	resource "awx_system_job_template_schedule" "cleanup_jobs" {
+	  system_job_template_id = data.awx_system_job_template.cleanup_jobs.id
+	  name                   = "Cleanup Job Schedule"
+	  rrule                  = "DTSTART:20240101T030000Z RRULE:FREQ=WEEKLY;INTERVAL=1;BYDAY=SU"
+	  extra_data             = jsonencode({ "days" = 30 })
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const schedulesAPIEndpoint = "/api/v2/schedules/"
+
+var scheduleFieldAttributes = map[string]string{
+	"unified_job_template": "system_job_template_id",
+}
+
+type schedule struct {
+	ID                 int                    `json:"id"`
+	Name               string                 `json:"name"`
+	Description        string                 `json:"description"`
+	Rrule              string                 `json:"rrule"`
+	Enabled            bool                   `json:"enabled"`
+	ExtraData          map[string]interface{} `json:"extra_data"`
+	UnifiedJobTemplate int                    `json:"unified_job_template"`
+	NextRun            string                 `json:"next_run"`
+}
+
+func resourceSystemJobTemplateSchedule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSystemJobTemplateScheduleCreate,
+		ReadContext:   resourceSystemJobTemplateScheduleRead,
+		UpdateContext: resourceSystemJobTemplateScheduleUpdate,
+		DeleteContext: resourceSystemJobTemplateScheduleDelete,
+
+		Schema: map[string]*schema.Schema{
+			"system_job_template_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Numeric ID of the system job template",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the schedule",
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"rrule": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Recurrence of the schedule, a DTSTART and an RRULE as the AWX UI creates them",
+			},
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"extra_data": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				StateFunc:    normalizeJsonYaml,
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "Extra data of the system jobs in JSON, like the retention days",
+			},
+			"next_run": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the next run of the schedule",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceSystemJobTemplateScheduleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	systemJobTemplateID := d.Get("system_job_template_id").(int)
+	data, err := expandSchedule(d)
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"Create: Invalid extra_data",
+			"Schedule %s has invalid extra_data: %s", d.Get("name").(string), err.Error(),
+		)
+	}
+
+	result := new(schedule)
+	if err := apiPost(m, fmt.Sprintf("%s%d/schedules/", systemJobTemplatesAPIEndpoint, systemJobTemplateID), data, result); err != nil {
+		log.Printf("Fail to create Schedule %v", err)
+		return buildDiagAPIFail(
+			"Unable to create Schedule", err, scheduleFieldAttributes,
+			"Schedule %s of SystemJobTemplate with id %d faild to create %s", d.Get("name").(string), systemJobTemplateID, err.Error(),
+		)
+	}
+	d.SetId(strconv.Itoa(result.ID))
+	return resourceSystemJobTemplateScheduleRead(ctx, d, m)
+}
+
+func resourceSystemJobTemplateScheduleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Update Schedule", d)
+	if diags.HasError() {
+		return diags
+	}
+	data, err := expandSchedule(d)
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"Update: Invalid extra_data",
+			"Schedule with id %d has invalid extra_data: %s", id, err.Error(),
+		)
+	}
+
+	if err := apiPatch(m, fmt.Sprintf("%s%d/", schedulesAPIEndpoint, id), data, nil); err != nil {
+		return buildDiagAPIFail(
+			"Update: Fail To Update Schedule", err, scheduleFieldAttributes,
+			"Fail to update Schedule with ID %v, got %s", id, err.Error(),
+		)
+	}
+	return resourceSystemJobTemplateScheduleRead(ctx, d, m)
+}
+
+func resourceSystemJobTemplateScheduleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read Schedule", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	res := new(schedule)
+	if err := apiGet(m, fmt.Sprintf("%s%d/", schedulesAPIEndpoint, id), res, nil); err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "schedule", id)
+		}
+		return buildDiagNotFoundFail("schedule", id, err)
+	}
+	setScheduleResourceData(d, res)
+	return diags
+}
+
+func resourceSystemJobTemplateScheduleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Delete Schedule", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := apiDelete(m, fmt.Sprintf("%s%d/", schedulesAPIEndpoint, id)); err != nil {
+		return buildDiagDeleteFail(
+			"Schedule",
+			fmt.Sprintf("ScheduleID %v, got %s ", id, err.Error()),
+		)
+	}
+	d.SetId("")
+	return diags
+}
+
+func expandSchedule(d *schema.ResourceData) (map[string]interface{}, error) {
+	extraData, err := expandExtraVars(d.Get("extra_data").(string))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"rrule":       d.Get("rrule").(string),
+		"enabled":     d.Get("enabled").(bool),
+		"extra_data":  extraData,
+	}, nil
+}
+
+func setScheduleResourceData(d *schema.ResourceData, r *schedule) *schema.ResourceData {
+	extraData := ""
+	if len(r.ExtraData) > 0 {
+		b, _ := json.Marshal(r.ExtraData)
+		extraData = string(b)
+	}
+	d.Set("system_job_template_id", r.UnifiedJobTemplate)
+	d.Set("name", r.Name)
+	d.Set("description", r.Description)
+	d.Set("rrule", r.Rrule)
+	d.Set("enabled", r.Enabled)
+	d.Set("extra_data", extraData)
+	d.Set("next_run", r.NextRun)
+	return d
+}
@@ -0,0 +1,318 @@
+/*
+Approves or denies the approval node of a running workflow job. The resource
+waits until the workflow job reaches the node, acts on the pending approval and
+waits until the workflow job moved past the node. Destroying the resource keeps
+the approval in AWX.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_workflow_approval" "release" {
+	  workflow_job_template_id = awx_workflow_job_template.release.id
+	  node_identifier          = "release-gate"
+	  action                   = "approve"
+
+	  triggers = {
+	    checks = data.external.checks.result["sha"]
+	  }
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const workflowApprovalsAPIEndpoint = "/api/v2/workflow_approvals/"
+
+// workflowApprovalSelectors are the arguments the workflow job is looked up by.
+var workflowApprovalSelectors = []string{"workflow_job_id", "workflow_job_template_id"}
+
+type workflowApproval struct {
+	unifiedJob
+	SummaryFields struct {
+		SourceWorkflowJob struct {
+			ID int `json:"id"`
+		} `json:"source_workflow_job"`
+		ApprovedOrDeniedBy struct {
+			Username string `json:"username"`
+		} `json:"approved_or_denied_by"`
+	} `json:"summary_fields"`
+}
+
+func resourceWorkflowApproval() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWorkflowApprovalCreate,
+		ReadContext:   resourceWorkflowApprovalRead,
+		DeleteContext: resourceWorkflowApprovalDelete,
+
+		Schema: map[string]*schema.Schema{
+			"workflow_job_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: workflowApprovalSelectors,
+				Description:  "Numeric ID of the workflow job",
+			},
+			"workflow_job_template_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: workflowApprovalSelectors,
+				Description:  "Numeric ID of the workflow job template, the latest workflow job of the template is used",
+			},
+			"node_identifier": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Identifier of the approval node in the workflow job template",
+			},
+			"action": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"approve", "deny"}, false),
+				Description:  "One of approve, deny",
+			},
+			"triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values, the approval is looked up and acted on again when it changes",
+			},
+			"workflow_approval_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Numeric ID of the workflow approval",
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the workflow approval, successful when approved and failed when denied",
+			},
+			"approved_by": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Username of the user who approved or denied",
+			},
+			"approved_at": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the approval or denial, RFC 3339",
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+	}
+}
+
+func resourceWorkflowApprovalCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	identifier := d.Get("node_identifier").(string)
+	action := d.Get("action").(string)
+
+	workflowJobID, diags := findWorkflowJobID(d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	var node *workflowJobNode
+	err := waitForCondition(ctx, fmt.Sprintf("node %s of workflow job %d to start", identifier, workflowJobID), func() (bool, error) {
+		var err error
+		node, err = findWorkflowJobNode(m, workflowJobID, identifier)
+		if err != nil {
+			return false, err
+		}
+		if node.Job != 0 {
+			return true, nil
+		}
+		if node.DoNotRun {
+			return false, fmt.Errorf("node %s of workflow job %d doesn't run", identifier, workflowJobID)
+		}
+		return false, checkWorkflowJobRunning(m, workflowJobID)
+	})
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"Unable to find WorkflowApproval",
+			"WorkflowJob %d has no approval for node %s: %s", workflowJobID, identifier, err.Error(),
+		)
+	}
+
+	approval, err := getWorkflowApproval(m, node.Job)
+	if err != nil {
+		return buildDiagNotFoundFail("workflow approval", node.Job, err)
+	}
+	if approval.Status != "pending" {
+		return buildDiagnosticsMessage(
+			"Unable to act on WorkflowApproval",
+			"WorkflowApproval %d of node %s is %s, only pending approvals can be approved or denied", approval.ID, identifier, approval.Status,
+		)
+	}
+
+	if err := apiPost(m, fmt.Sprintf("%s%d/%s/", workflowApprovalsAPIEndpoint, approval.ID, action), map[string]interface{}{}, nil); err != nil {
+		log.Printf("Fail to %s WorkflowApproval %v", action, err)
+		return buildDiagAPIFail(
+			"Unable to act on WorkflowApproval", err, nil,
+			"WorkflowApproval %d of node %s faild to %s %s", approval.ID, identifier, action, err.Error(),
+		)
+	}
+	d.SetId(strconv.Itoa(approval.ID))
+	d.Set("workflow_job_id", workflowJobID)
+
+	err = waitForCondition(ctx, fmt.Sprintf("workflow job %d to move past node %s", workflowJobID, identifier), func() (bool, error) {
+		return workflowJobPassedNode(m, workflowJobID, node)
+	})
+	diags = append(diags, resourceWorkflowApprovalRead(ctx, d, m)...)
+	if err != nil {
+		diags = append(diags, buildDiagnosticsMessage(
+			"Unable to wait for WorkflowJob",
+			"WorkflowJob %d didn't move past node %s: %s", workflowJobID, identifier, err.Error(),
+		)...)
+	}
+	return diags
+}
+
+func resourceWorkflowApprovalRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read WorkflowApproval", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	res, err := getWorkflowApproval(m, id)
+	if err != nil {
+		if isNotFound(err) {
+			// AWX cleans up old workflow jobs, that must not act on a new approval.
+			log.Printf("[WARN] workflow approval %d not found in AWX, keeping it in the state", id)
+			return nil
+		}
+		return buildDiagNotFoundFail("workflow approval", id, err)
+	}
+	setWorkflowApprovalResourceData(d, res)
+	return nil
+}
+
+func resourceWorkflowApprovalDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Approvals are part of the AWX history, only the state is cleared.
+	d.SetId("")
+	return nil
+}
+
+func getWorkflowApproval(m interface{}, id int) (*workflowApproval, error) {
+	result := new(workflowApproval)
+	if err := apiGet(m, fmt.Sprintf("%s%d/", workflowApprovalsAPIEndpoint, id), result, nil); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// findWorkflowJobID returns the workflow_job_id, or the id of the latest
+// workflow job of workflow_job_template_id.
+func findWorkflowJobID(d *schema.ResourceData, m interface{}) (int, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if id, ok := d.GetOk("workflow_job_id"); ok {
+		return id.(int), diags
+	}
+
+	workflowJobTemplateID := d.Get("workflow_job_template_id").(int)
+	page := new(apiPage)
+	err := apiGet(m, workflowJobsAPIEndpoint, page, map[string]string{
+		"workflow_job_template": strconv.Itoa(workflowJobTemplateID),
+		"order_by":              "-id",
+		"page_size":             "1",
+	})
+	if err != nil {
+		return 0, buildDiagNotFoundFail("workflow jobs of workflow job template", workflowJobTemplateID, err)
+	}
+	var jobs []unifiedJob
+	if err := json.Unmarshal(page.Results, &jobs); err != nil {
+		return 0, buildDiagNotFoundFail("workflow jobs of workflow job template", workflowJobTemplateID, err)
+	}
+	if len(jobs) == 0 {
+		return 0, buildDiagnosticsMessage(
+			"Get: WorkflowJob not found",
+			"WorkflowJobTemplate with id %d has no workflow job",
+			workflowJobTemplateID,
+		)
+	}
+	return jobs[0].ID, diags
+}
+
+func findWorkflowJobNode(m interface{}, workflowJobID int, identifier string) (*workflowJobNode, error) {
+	nodes, err := listWorkflowJobNodes(m, workflowJobID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range nodes {
+		if nodes[i].Identifier == identifier {
+			return &nodes[i], nil
+		}
+	}
+	return nil, fmt.Errorf("workflow job %d has no node %s", workflowJobID, identifier)
+}
+
+// checkWorkflowJobRunning fails once the workflow job finished, a node that
+// didn't start by then never starts.
+func checkWorkflowJobRunning(m interface{}, workflowJobID int) error {
+	job, err := getWorkflowJob(m, workflowJobID)
+	if err != nil {
+		return err
+	}
+	if unifiedJobFinalStatuses[job.Status] {
+		return fmt.Errorf("workflow job %d finished with status %s", workflowJobID, job.Status)
+	}
+	return nil
+}
+
+// workflowJobPassedNode reports whether every child of node has started or
+// won't run, or the workflow job finished.
+func workflowJobPassedNode(m interface{}, workflowJobID int, node *workflowJobNode) (bool, error) {
+	job, err := getWorkflowJob(m, workflowJobID)
+	if err != nil {
+		return false, err
+	}
+	if unifiedJobFinalStatuses[job.Status] {
+		return true, nil
+	}
+	nodes, err := listWorkflowJobNodes(m, workflowJobID)
+	if err != nil {
+		return false, err
+	}
+	decided := map[int]bool{}
+	for _, n := range nodes {
+		decided[n.ID] = n.Job != 0 || n.DoNotRun
+	}
+	for _, children := range [][]int{node.SuccessNodes, node.FailureNodes, node.AlwaysNodes} {
+		for _, child := range children {
+			if !decided[child] {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+func setWorkflowApprovalResourceData(d *schema.ResourceData, r *workflowApproval) *schema.ResourceData {
+	if r.SummaryFields.SourceWorkflowJob.ID != 0 {
+		d.Set("workflow_job_id", r.SummaryFields.SourceWorkflowJob.ID)
+	}
+	d.Set("workflow_approval_id", r.ID)
+	d.Set("status", r.Status)
+	d.Set("approved_by", r.SummaryFields.ApprovedOrDeniedBy.Username)
+	d.Set("approved_at", formatJobTime(r.Finished))
+	return d
+}
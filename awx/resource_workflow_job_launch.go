@@ -0,0 +1,277 @@
+/*
+Launches a workflow job template and waits until the workflow job is finished.
+The workflow job is launched again when one of the arguments or the triggers
+change, destroying the resource keeps the workflow job in AWX.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_workflow_job_launch" "deploy" {
+	  workflow_job_template_id = awx_workflow_job_template.deploy.id
+	  extra_vars               = jsonencode({ "version" = var.version })
+	  fail_on_job_failure      = true
+
+	  triggers = {
+	    version = var.version
+	  }
+
+	  timeouts {
+	    create = "2h"
+	  }
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+const workflowJobsAPIEndpoint = "/api/v2/workflow_jobs/"
+
+type workflowJobLaunch struct {
+	WorkflowJob   int               `json:"workflow_job"`
+	IgnoredFields map[string]string `json:"ignored_fields"`
+}
+
+type workflowJobNode struct {
+	ID                 int    `json:"id"`
+	Identifier         string `json:"identifier"`
+	UnifiedJobTemplate int    `json:"unified_job_template"`
+	Job                int    `json:"job"`
+	DoNotRun           bool   `json:"do_not_run"`
+	SuccessNodes       []int  `json:"success_nodes"`
+	FailureNodes       []int  `json:"failure_nodes"`
+	AlwaysNodes        []int  `json:"always_nodes"`
+	SummaryFields      struct {
+		Job struct {
+			Status string `json:"status"`
+		} `json:"job"`
+	} `json:"summary_fields"`
+}
+
+// status returns the status of the job spawned by the node, or do_not_run
+// for nodes skipped by the workflow.
+func (n *workflowJobNode) status() string {
+	if n.Job == 0 && n.DoNotRun {
+		return "do_not_run"
+	}
+	return n.SummaryFields.Job.Status
+}
+
+func resourceWorkflowJobLaunch() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWorkflowJobLaunchCreate,
+		ReadContext:   resourceWorkflowJobLaunchRead,
+		UpdateContext: resourceWorkflowJobLaunchUpdate,
+		DeleteContext: resourceWorkflowJobLaunchDelete,
+
+		Schema: unifiedJobSchema("workflow job", map[string]*schema.Schema{
+			"workflow_job_template_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Numeric ID of the workflow job template to launch",
+			},
+			"extra_vars": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				StateFunc:   normalizeJsonYaml,
+				Description: "Extra variables in JSON or YAML, the workflow job template has to prompt for variables or define a survey",
+			},
+			"inventory_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Inventory applied as a prompt, assuming the workflow job template prompts for inventory",
+			},
+			"limit": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Host pattern applied as a prompt, assuming the workflow job template prompts for limit",
+			},
+			"scm_branch": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Branch applied as a prompt, assuming the workflow job template prompts for the SCM branch",
+			},
+			"triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values, the workflow job is launched again when it changes",
+			},
+			"workflow_job_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Numeric ID of the launched workflow job",
+			},
+			"nodes": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Results of the workflow nodes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"identifier": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Identifier of the workflow job template node",
+						},
+						"job_id": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Numeric ID of the job spawned by the node, 0 when the node didn't run",
+						},
+						"status": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the job spawned by the node, do_not_run for skipped nodes",
+						},
+					},
+				},
+			},
+		}),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+	}
+}
+
+func resourceWorkflowJobLaunchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	workflowJobTemplateID := d.Get("workflow_job_template_id").(int)
+
+	data := map[string]interface{}{}
+	if v, ok := d.GetOk("extra_vars"); ok {
+		data["extra_vars"] = v.(string)
+	}
+	if v, ok := d.GetOk("inventory_id"); ok {
+		data["inventory"] = v.(int)
+	}
+	for _, key := range []string{"limit", "scm_branch"} {
+		if v, ok := d.GetOk(key); ok {
+			data[key] = v.(string)
+		}
+	}
+
+	result := new(workflowJobLaunch)
+	err := apiPost(m, fmt.Sprintf("%s%d/launch/", workflowJobTemplatesAPIEndpoint, workflowJobTemplateID), data, result)
+	if err == nil && result.WorkflowJob == 0 {
+		err = fmt.Errorf("invalid workflow job id 0")
+	}
+	if err != nil {
+		log.Printf("Fail to launch WorkflowJobTemplate %v", err)
+		return buildDiagAPIFail(
+			"Unable to launch WorkflowJobTemplate", err, jobLaunchFieldAttributes,
+			"WorkflowJobTemplate with id %d faild to launch %s", workflowJobTemplateID, err.Error(),
+		)
+	}
+	d.SetId(strconv.Itoa(result.WorkflowJob))
+	diags = append(diags, buildDiagIgnoredLaunchFields("WorkflowJobTemplate", workflowJobTemplateID, result.IgnoredFields)...)
+
+	return append(diags, waitForLaunchedJob(ctx, d, m, launchedJob{
+		kind:     "WorkflowJob",
+		endpoint: workflowJobsAPIEndpoint,
+		id:       result.WorkflowJob,
+		origin:   fmt.Sprintf("of WorkflowJobTemplate with id %d", workflowJobTemplateID),
+		failures: describeFailedWorkflowNodes,
+	}, resourceWorkflowJobLaunchRead)...)
+}
+
+func resourceWorkflowJobLaunchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only fail_on_job_failure can change without launching a new workflow job.
+	return resourceWorkflowJobLaunchRead(ctx, d, m)
+}
+
+func resourceWorkflowJobLaunchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read WorkflowJob", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	res, diags := getLaunchedJob(m, "workflow job", workflowJobsAPIEndpoint, id)
+	if res == nil {
+		return diags
+	}
+	nodes, err := listWorkflowJobNodes(m, id)
+	if err != nil {
+		return buildDiagNotFoundFail("workflow job nodes", id, err)
+	}
+	setWorkflowJobLaunchResourceData(d, res, nodes)
+	return nil
+}
+
+func resourceWorkflowJobLaunchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Workflow jobs are part of the AWX history, only the state is cleared.
+	d.SetId("")
+	return nil
+}
+
+func getWorkflowJob(m interface{}, id int) (*unifiedJob, error) {
+	return getUnifiedJob(m, workflowJobsAPIEndpoint, id)
+}
+
+func listWorkflowJobNodes(m interface{}, id int) ([]workflowJobNode, error) {
+	var nodes []workflowJobNode
+	err := apiGetAll(m, fmt.Sprintf("%s%d/workflow_nodes/", workflowJobsAPIEndpoint, id), map[string]string{"order_by": "id"}, func(results json.RawMessage) error {
+		var page []workflowJobNode
+		if err := json.Unmarshal(results, &page); err != nil {
+			return err
+		}
+		nodes = append(nodes, page...)
+		return nil
+	})
+	return nodes, err
+}
+
+func setWorkflowJobLaunchResourceData(d *schema.ResourceData, r *unifiedJob, nodes []workflowJobNode) *schema.ResourceData {
+	d.Set("workflow_job_id", r.ID)
+	setUnifiedJobResourceData(d, r)
+
+	nodeResults := make([]interface{}, 0, len(nodes))
+	for _, node := range nodes {
+		nodeResults = append(nodeResults, map[string]interface{}{
+			"identifier": node.Identifier,
+			"job_id":     node.Job,
+			"status":     node.status(),
+		})
+	}
+	d.Set("nodes", nodeResults)
+	return d
+}
+
+// describeFailedWorkflowNodes lists the nodes of the state whose job didn't
+// succeed, for the failure diagnostic.
+func describeFailedWorkflowNodes(d *schema.ResourceData) string {
+	var failed []string
+	for _, item := range d.Get("nodes").([]interface{}) {
+		node := item.(map[string]interface{})
+		switch status := node["status"].(string); status {
+		case awx.JobStatusFailed, awx.JobStatusError, awx.JobStatusCanceled:
+			failed = append(failed, fmt.Sprintf("%s (job %d, %s)", node["identifier"], node["job_id"], status))
+		}
+	}
+	if len(failed) == 0 {
+		return ""
+	}
+	sort.Strings(failed)
+	return ", failed nodes: " + strings.Join(failed, ", ")
+}
@@ -62,6 +62,7 @@ func resourceWorkflowJobTemplate() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"survey": surveySchema,
 			"allow_simultaneous": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -125,8 +126,13 @@ func resourceWorkflowJobTemplate() *schema.Resource {
 	}
 }
 
+// workflowJobTemplateFieldAttributes maps the AWX fields of a workflow job
+// template that are named differently in the resource.
+var workflowJobTemplateFieldAttributes = map[string]string{
+	"extra_vars": "variables",
+}
+
 func resourceWorkflowJobTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
 	awxService := client.WorkflowJobTemplateService
 
@@ -149,15 +155,21 @@ func resourceWorkflowJobTemplateCreate(ctx context.Context, d *schema.ResourceDa
 	}, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Template %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create WorkflowJobTemplate",
-			Detail:   fmt.Sprintf("WorkflowJobTemplate with name %s faild to create %s", d.Get("name").(string), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail(
+			"Unable to create WorkflowJobTemplate", err, workflowJobTemplateFieldAttributes,
+			"WorkflowJobTemplate with name %s faild to create %s", d.Get("name").(string), err.Error(),
+		)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
+	if _, ok := d.GetOk("survey"); ok {
+		if err := updateSurveySpec(d, m, workflowJobTemplatesAPIEndpoint, result.ID); err != nil {
+			return buildDiagAPIFail(
+				"Unable to create WorkflowJobTemplate survey", err, surveyFieldAttributes,
+				"Survey of WorkflowJobTemplate with id %d faild to create %s", result.ID, err.Error(),
+			)
+		}
+	}
 	return resourceWorkflowJobTemplateRead(ctx, d, m)
 }
 
@@ -194,12 +206,18 @@ func resourceWorkflowJobTemplateUpdate(ctx context.Context, d *schema.ResourceDa
 		"webhook_credential":       d.Get("webhook_credential").(string),
 	}, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update WorkflowJobTemplate",
-			Detail:   fmt.Sprintf("WorkflowJobTemplate with name %s in the project id %d faild to update %s", d.Get("name").(string), d.Get("project_id").(int), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail(
+			"Unable to update WorkflowJobTemplate", err, workflowJobTemplateFieldAttributes,
+			"WorkflowJobTemplate with name %s faild to update %s", d.Get("name").(string), err.Error(),
+		)
+	}
+	if d.HasChange("survey") {
+		if err := updateSurveySpec(d, m, workflowJobTemplatesAPIEndpoint, id); err != nil {
+			return buildDiagAPIFail(
+				"Unable to update WorkflowJobTemplate survey", err, surveyFieldAttributes,
+				"Survey of WorkflowJobTemplate with id %d faild to update %s", id, err.Error(),
+			)
+		}
 	}
 
 	return resourceWorkflowJobTemplateRead(ctx, d, m)
@@ -216,10 +234,16 @@ func resourceWorkflowJobTemplateRead(ctx context.Context, d *schema.ResourceData
 
 	res, err := awxService.GetWorkflowJobTemplateByID(id, make(map[string]string))
 	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "workflow job template", id)
+		}
 		return buildDiagNotFoundFail("workflow job template", id, err)
 
 	}
 	d = setWorkflowJobTemplateResourceData(d, res)
+	if err := readSurveySpec(d, m, workflowJobTemplatesAPIEndpoint, id); err != nil {
+		return buildDiagNotFoundFail("workflow job template survey", id, err)
+	}
 	return nil
 }
 
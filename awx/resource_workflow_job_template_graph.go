@@ -0,0 +1,964 @@
+/*
+Manages every node of a workflow job template and the edges between them in
+one resource. Nodes are keyed by their identifier, a node may have several
+parents. Applying compares the graph with the nodes in AWX and only creates,
+updates, deletes, links and unlinks what changed. Nodes of the workflow job
+template that aren't configured are deleted, don't combine this resource with
+the awx_workflow_job_template_node resources on the same workflow.
+
+# Example Usage
+
+```hcl
+
+	resource "awx_workflow_job_template_graph" "deploy" {
+	  workflow_job_template_id = awx_workflow_job_template.deploy.id
+
+	  node {
+	    identifier = "sync"
+	    project_id = awx_project.deploy.id
+	    success    = ["build", "lint"]
+	  }
+
+	  node {
+	    identifier              = "build"
+	    unified_job_template_id = awx_job_template.build.id
+	    success                 = ["gate"]
+	  }
+
+	  node {
+	    identifier              = "lint"
+	    unified_job_template_id = awx_job_template.lint.id
+	    success                 = ["gate"]
+	  }
+
+	  node {
+	    identifier = "gate"
+	    success    = ["release"]
+
+	    approval {
+	      name    = "Release"
+	      timeout = 3600
+	    }
+	  }
+
+	  node {
+	    identifier              = "release"
+	    unified_job_template_id = awx_job_template.release.id
+	    limit                   = "production"
+	  }
+	}
+
+```
+*/
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// workflowGraphEdges maps the edge arguments of a node to the AWX node lists.
+var workflowGraphEdges = map[string]string{
+	"success": "success_nodes",
+	"failure": "failure_nodes",
+	"always":  "always_nodes",
+}
+
+// workflowGraphTargetNodeTypes maps the target arguments of a node to its node type.
+var workflowGraphTargetNodeTypes = map[string]string{
+	"unified_job_template_id":         "job_template",
+	"project_id":                      "project",
+	"inventory_source_id":             "inventory_source",
+	"nested_workflow_job_template_id": "workflow_job_template",
+	"approval":                        "approval",
+}
+
+// workflowGraphNode is a node of a workflow job template as AWX returns it.
+type workflowGraphNode struct {
+	ID                     int                    `json:"id"`
+	Identifier             string                 `json:"identifier"`
+	UnifiedJobTemplate     int                    `json:"unified_job_template"`
+	SuccessNodes           []int                  `json:"success_nodes"`
+	FailureNodes           []int                  `json:"failure_nodes"`
+	AlwaysNodes            []int                  `json:"always_nodes"`
+	Inventory              int                    `json:"inventory"`
+	Limit                  string                 `json:"limit"`
+	ScmBranch              string                 `json:"scm_branch"`
+	JobTags                string                 `json:"job_tags"`
+	SkipTags               string                 `json:"skip_tags"`
+	ExtraData              map[string]interface{} `json:"extra_data"`
+	JobType                string                 `json:"job_type"`
+	DiffMode               *bool                  `json:"diff_mode"`
+	Verbosity              *int                   `json:"verbosity"`
+	ExecutionEnvironment   int                    `json:"execution_environment"`
+	Forks                  *int                   `json:"forks"`
+	Timeout                *int                   `json:"timeout"`
+	JobSliceCount          *int                   `json:"job_slice_count"`
+	AllParentsMustConverge bool                   `json:"all_parents_must_converge"`
+	SummaryFields          struct {
+		UnifiedJobTemplate struct {
+			UnifiedJobType string `json:"unified_job_type"`
+		} `json:"unified_job_template"`
+	} `json:"summary_fields"`
+}
+
+func (n *workflowGraphNode) edges(kind string) []int {
+	switch kind {
+	case "success":
+		return n.SuccessNodes
+	case "failure":
+		return n.FailureNodes
+	default:
+		return n.AlwaysNodes
+	}
+}
+
+// desiredGraphNode is a node of the configuration.
+type desiredGraphNode struct {
+	Identifier string
+	Target     string
+	TemplateID int
+	Approval   *workflowApprovalTemplate
+	Fields     map[string]interface{}
+	// Associations holds the prompted credentials, labels and instance groups
+	// by argument, for the relations the target accepts.
+	Associations map[string][]int
+	Edges        map[string][]string
+}
+
+func resourceWorkflowJobTemplateGraph() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWorkflowJobTemplateGraphCreate,
+		ReadContext:   resourceWorkflowJobTemplateGraphRead,
+		UpdateContext: resourceWorkflowJobTemplateGraphUpdate,
+		DeleteContext: resourceWorkflowJobTemplateGraphDelete,
+		CustomizeDiff: resourceWorkflowJobTemplateGraphCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"workflow_job_template_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Numeric ID of the workflow job template",
+			},
+			"node": &schema.Schema{
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Nodes of the workflow",
+				Elem: &schema.Resource{
+					Schema: addWorkflowNodePromptSchema(map[string]*schema.Schema{
+						"identifier": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Identifier of the node, unique within the workflow",
+						},
+						"unified_job_template_id": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+							Description: "Numeric ID of the job template or other unified job template the node runs",
+						},
+						"project_id": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+							Description: "Numeric ID of the project the node updates",
+						},
+						"inventory_source_id": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+							Description: "Numeric ID of the inventory source the node syncs",
+						},
+						"nested_workflow_job_template_id": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+							Description: "Numeric ID of the workflow job template the node runs as a nested workflow",
+						},
+						"approval": &schema.Schema{
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Makes the node an approval node",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": &schema.Schema{
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Name of the approval",
+									},
+									"description": &schema.Schema{
+										Type:        schema.TypeString,
+										Optional:    true,
+										Default:     "",
+										Description: "Description of the approval",
+									},
+									"timeout": &schema.Schema{
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      0,
+										ValidateFunc: validation.IntAtLeast(0),
+										Description:  "Seconds until the approval is denied, 0 waits forever",
+									},
+								},
+							},
+						},
+						"inventory_id": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Inventory applied as a prompt, assuming the template prompts for inventory",
+						},
+						"limit": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Host pattern applied as a prompt, assuming the template prompts for limit",
+						},
+						"scm_branch": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Branch applied as a prompt, assuming the template prompts for the SCM branch",
+						},
+						"job_tags": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Tags applied as a prompt, assuming the template prompts for tags",
+						},
+						"skip_tags": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Skipped tags applied as a prompt, assuming the template prompts for skipped tags",
+						},
+						"extra_data": workflowNodeExtraDataSchema(),
+						"job_type": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"run", "check"}, false),
+							Description:  "One of run, check, applied as a prompt, assuming the job template prompts for the job type",
+						},
+						"diff_mode": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Diff mode applied as a prompt, assuming the job template prompts for diff mode",
+						},
+						"verbosity": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, 5),
+							Description:  "One of 0,1,2,3,4,5, applied as a prompt, assuming the job template prompts for verbosity",
+						},
+						"all_parents_must_converge": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Run the node only when all parents reached it, instead of any, true by default like awx_workflow_job_template_node",
+						},
+						"success": &schema.Schema{
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Identifiers of the nodes run when this node succeeds",
+						},
+						"failure": &schema.Schema{
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Identifiers of the nodes run when this node fails",
+						},
+						"always": &schema.Schema{
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Identifiers of the nodes run after this node in any case",
+						},
+						"node_type": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "One of job_template, project, inventory_source, workflow_job_template, approval, system_job_template",
+						},
+					}),
+				},
+			},
+			"node_ids": &schema.Schema{
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Numeric IDs of the nodes by identifier",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func resourceWorkflowJobTemplateGraphCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	workflowJobTemplateID := d.Get("workflow_job_template_id").(int)
+	if diags := syncWorkflowGraph(d, m, workflowJobTemplateID); diags.HasError() {
+		return diags
+	}
+	d.SetId(strconv.Itoa(workflowJobTemplateID))
+	return resourceWorkflowJobTemplateGraphRead(ctx, d, m)
+}
+
+func resourceWorkflowJobTemplateGraphUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Update WorkflowJobTemplateGraph", d)
+	if diags.HasError() {
+		return diags
+	}
+	if diags := syncWorkflowGraph(d, m, id); diags.HasError() {
+		return diags
+	}
+	return resourceWorkflowJobTemplateGraphRead(ctx, d, m)
+}
+
+func resourceWorkflowJobTemplateGraphRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read WorkflowJobTemplateGraph", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	live, err := listWorkflowGraphNodes(m, id)
+	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "workflow job template graph", id)
+		}
+		return buildDiagNotFoundFail("workflow job template nodes", id, err)
+	}
+	identifiers := map[int]string{}
+	byIdentifier := map[string]*workflowGraphNode{}
+	for i := range live {
+		identifiers[live[i].ID] = live[i].Identifier
+		byIdentifier[live[i].Identifier] = &live[i]
+	}
+
+	// Keep the order of the state, nodes added outside of terraform follow.
+	var order []string
+	seen := map[string]bool{}
+	for _, item := range d.Get("node").([]interface{}) {
+		identifier := item.(map[string]interface{})["identifier"].(string)
+		if byIdentifier[identifier] != nil && !seen[identifier] {
+			order = append(order, identifier)
+			seen[identifier] = true
+		}
+	}
+	var added []string
+	for identifier := range byIdentifier {
+		if !seen[identifier] {
+			added = append(added, identifier)
+		}
+	}
+	sort.Strings(added)
+	order = append(order, added...)
+
+	nodes := make([]interface{}, 0, len(order))
+	nodeIDs := map[string]interface{}{}
+	for _, identifier := range order {
+		node, err := flattenWorkflowGraphNode(m, byIdentifier[identifier], identifiers)
+		if err != nil {
+			return buildDiagnosticsMessage(
+				"Unable to read WorkflowJobTemplateGraph node",
+				"Unable to read node %s with id %d of WorkflowJobTemplate with id %d: %s", identifier, byIdentifier[identifier].ID, id, err.Error(),
+			)
+		}
+		nodes = append(nodes, node)
+		nodeIDs[identifier] = byIdentifier[identifier].ID
+	}
+
+	d.Set("workflow_job_template_id", id)
+	d.Set("node", nodes)
+	d.Set("node_ids", nodeIDs)
+	return diags
+}
+
+func resourceWorkflowJobTemplateGraphDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Delete WorkflowJobTemplateGraph", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	live, err := listWorkflowGraphNodes(m, id)
+	if err != nil && !isNotFound(err) {
+		return buildDiagNotFoundFail("workflow job template nodes", id, err)
+	}
+	for _, node := range live {
+		if err := apiDelete(m, fmt.Sprintf("%s%d/", workflowJobTemplateNodesAPIEndpoint, node.ID)); err != nil {
+			return buildDiagDeleteFail(
+				"WorkflowJobTemplateNode",
+				fmt.Sprintf("id %v of node %s, got %s ", node.ID, node.Identifier, err.Error()),
+			)
+		}
+	}
+	d.SetId("")
+	return diags
+}
+
+// resourceWorkflowJobTemplateGraphCustomizeDiff rejects duplicate identifiers,
+// edges to unknown nodes and cycles at plan time. Graphs with identifiers that
+// are only known after apply are checked on apply.
+func resourceWorkflowJobTemplateGraphCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	edges := map[string][]string{}
+	for _, item := range d.Get("node").([]interface{}) {
+		node, ok := item.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		identifier := node["identifier"].(string)
+		if identifier == "" {
+			return nil
+		}
+		if _, ok := edges[identifier]; ok {
+			return fmt.Errorf("node identifier %q is used more than once", identifier)
+		}
+		edges[identifier] = []string{}
+		for kind := range workflowGraphEdges {
+			set, ok := node[kind].(*schema.Set)
+			if !ok {
+				continue
+			}
+			for _, child := range set.List() {
+				if child.(string) == "" {
+					return nil
+				}
+				edges[identifier] = append(edges[identifier], child.(string))
+			}
+		}
+	}
+	return validateWorkflowGraph(edges)
+}
+
+// validateWorkflowGraph fails for edges to unknown nodes and for cycles.
+func validateWorkflowGraph(edges map[string][]string) error {
+	identifiers := make([]string, 0, len(edges))
+	for identifier := range edges {
+		identifiers = append(identifiers, identifier)
+	}
+	sort.Strings(identifiers)
+	for _, identifier := range identifiers {
+		for _, child := range edges[identifier] {
+			if _, ok := edges[child]; !ok {
+				return fmt.Errorf("node %q links to the unknown node %q", identifier, child)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	var path []string
+	var visit func(identifier string) error
+	visit = func(identifier string) error {
+		switch state[identifier] {
+		case visiting:
+			start := 0
+			for path[start] != identifier {
+				start++
+			}
+			return fmt.Errorf("the workflow has a cycle: %s -> %s", strings.Join(path[start:], " -> "), identifier)
+		case visited:
+			return nil
+		}
+		state[identifier] = visiting
+		path = append(path, identifier)
+		children := append([]string{}, edges[identifier]...)
+		sort.Strings(children)
+		for _, child := range children {
+			if err := visit(child); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[identifier] = visited
+		return nil
+	}
+	for _, identifier := range identifiers {
+		if err := visit(identifier); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncWorkflowGraph applies the configured graph to the workflow job template:
+// removed nodes are deleted, stale edges unlinked, new nodes created, changed
+// nodes updated and missing edges linked.
+func syncWorkflowGraph(d *schema.ResourceData, m interface{}, workflowJobTemplateID int) diag.Diagnostics {
+	desired, err := expandWorkflowGraphNodes(d)
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"Invalid WorkflowJobTemplateGraph",
+			"The graph of WorkflowJobTemplate with id %d is invalid: %s", workflowJobTemplateID, err.Error(),
+		)
+	}
+	desiredByIdentifier := map[string]*desiredGraphNode{}
+	for i := range desired {
+		desiredByIdentifier[desired[i].Identifier] = &desired[i]
+	}
+
+	live, err := listWorkflowGraphNodes(m, workflowJobTemplateID)
+	if err != nil {
+		return buildDiagNotFoundFail("workflow job template nodes", workflowJobTemplateID, err)
+	}
+	liveByIdentifier := map[string]*workflowGraphNode{}
+	identifiers := map[int]string{}
+	for i := range live {
+		liveByIdentifier[live[i].Identifier] = &live[i]
+		identifiers[live[i].ID] = live[i].Identifier
+	}
+
+	for _, node := range live {
+		if desiredByIdentifier[node.Identifier] != nil {
+			continue
+		}
+		log.Printf("[INFO] deleting node %s of workflow job template %d", node.Identifier, workflowJobTemplateID)
+		if err := apiDelete(m, fmt.Sprintf("%s%d/", workflowJobTemplateNodesAPIEndpoint, node.ID)); err != nil {
+			return buildDiagDeleteFail(
+				"WorkflowJobTemplateNode",
+				fmt.Sprintf("id %v of node %s, got %s ", node.ID, node.Identifier, err.Error()),
+			)
+		}
+	}
+
+	for _, node := range live {
+		want := desiredByIdentifier[node.Identifier]
+		if want == nil {
+			continue
+		}
+		for kind := range workflowGraphEdges {
+			for _, childID := range node.edges(kind) {
+				child := identifiers[childID]
+				if desiredByIdentifier[child] == nil || containsString(want.Edges[kind], child) {
+					continue
+				}
+				if diags := linkWorkflowGraphNodes(m, node.ID, kind, childID, node.Identifier, child, true); diags.HasError() {
+					return diags
+				}
+			}
+		}
+	}
+
+	nodeIDs := map[string]int{}
+	for i := range desired {
+		want := &desired[i]
+		current := liveByIdentifier[want.Identifier]
+		var diags diag.Diagnostics
+		if current == nil {
+			nodeIDs[want.Identifier], diags = createWorkflowGraphNode(m, workflowJobTemplateID, want)
+		} else {
+			nodeIDs[want.Identifier] = current.ID
+			diags = updateWorkflowGraphNode(m, want, current)
+		}
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	for i := range desired {
+		want := &desired[i]
+		current := liveByIdentifier[want.Identifier]
+		for kind, children := range want.Edges {
+			for _, child := range children {
+				childID := nodeIDs[child]
+				if current != nil && containsInt(current.edges(kind), childID) {
+					continue
+				}
+				if diags := linkWorkflowGraphNodes(m, nodeIDs[want.Identifier], kind, childID, want.Identifier, child, false); diags.HasError() {
+					return diags
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// expandWorkflowGraphNodes reads the nodes of the configuration. The target of
+// a node is taken from the raw configuration, the computed targets of the state
+// don't count.
+func expandWorkflowGraphNodes(d *schema.ResourceData) ([]desiredGraphNode, error) {
+	var rawNodes cty.Value
+	if config := d.GetRawConfig(); !config.IsNull() && config.IsKnown() {
+		rawNodes = config.GetAttr("node")
+	}
+
+	items := d.Get("node").([]interface{})
+	nodes := make([]desiredGraphNode, 0, len(items))
+	edges := map[string][]string{}
+	for i, item := range items {
+		node := item.(map[string]interface{})
+		want := desiredGraphNode{
+			Identifier: node["identifier"].(string),
+			Fields: map[string]interface{}{
+				"identifier":                node["identifier"].(string),
+				"all_parents_must_converge": node["all_parents_must_converge"].(bool),
+			},
+			Edges: map[string][]string{},
+		}
+		if _, ok := edges[want.Identifier]; ok {
+			return nil, fmt.Errorf("node identifier %q is used more than once", want.Identifier)
+		}
+
+		var rawNode cty.Value
+		if rawNodes != cty.NilVal && !rawNodes.IsNull() && rawNodes.IsKnown() && rawNodes.LengthInt() > i {
+			rawNode = rawNodes.Index(cty.NumberIntVal(int64(i)))
+		}
+		// isSet tells whether key is configured, without a raw configuration
+		// values other than the zero value count.
+		isSet := func(key string) bool {
+			if rawNode != cty.NilVal {
+				return !rawNode.GetAttr(key).IsNull()
+			}
+			return !reflect.ValueOf(node[key]).IsZero()
+		}
+
+		var targets []string
+		for _, key := range workflowNodeTargets {
+			if key == "approval" {
+				if approvals := node["approval"].([]interface{}); len(approvals) > 0 && approvals[0] != nil {
+					approval := approvals[0].(map[string]interface{})
+					want.Approval = &workflowApprovalTemplate{
+						Name:        approval["name"].(string),
+						Description: approval["description"].(string),
+						Timeout:     approval["timeout"].(int),
+					}
+					targets = append(targets, key)
+				}
+				continue
+			}
+			if isSet(key) {
+				want.TemplateID = node[key].(int)
+				targets = append(targets, key)
+			}
+		}
+		if len(targets) != 1 {
+			return nil, fmt.Errorf("node %q needs exactly one of %s, got %d", want.Identifier, strings.Join(workflowNodeTargets, ", "), len(targets))
+		}
+		want.Target = targets[0]
+
+		nodeType := workflowGraphTargetNodeTypes[want.Target]
+		if nodeType == "job_template" || nodeType == "workflow_job_template" {
+			extraData, err := expandExtraVars(node["extra_data"].(string))
+			if err != nil {
+				return nil, fmt.Errorf("node %q: %s", want.Identifier, err)
+			}
+			want.Fields["extra_data"] = extraData
+			want.Fields["inventory"] = nilIfZero(node["inventory_id"].(int))
+			want.Fields["execution_environment"] = nilIfZero(node["execution_environment_id"].(int))
+			for _, key := range []string{"limit", "scm_branch", "job_type", "job_tags", "skip_tags"} {
+				want.Fields[key] = nilIfZero(node[key].(string))
+			}
+			for _, key := range []string{"diff_mode", "verbosity", "forks", "timeout", "job_slice_count"} {
+				want.Fields[key] = nil
+				if isSet(key) {
+					want.Fields[key] = node[key]
+				}
+			}
+			if nodeType == "workflow_job_template" {
+				// Workflow job templates don't prompt for these.
+				for _, key := range workflowNodeJobOnlyPrompts {
+					delete(want.Fields, key)
+				}
+			}
+		}
+		want.Associations = map[string][]int{}
+		for key, relation := range workflowNodeAssociations {
+			ids := expandWorkflowNodeIDs(node[key])
+			if !workflowNodeAcceptsRelation(nodeType, relation) {
+				if len(ids) > 0 {
+					return nil, fmt.Errorf("node %q: %s can't be set on %s nodes", want.Identifier, key, nodeType)
+				}
+				continue
+			}
+			want.Associations[key] = ids
+		}
+
+		edges[want.Identifier] = []string{}
+		for kind := range workflowGraphEdges {
+			for _, child := range node[kind].(*schema.Set).List() {
+				want.Edges[kind] = append(want.Edges[kind], child.(string))
+				edges[want.Identifier] = append(edges[want.Identifier], child.(string))
+			}
+			sort.Strings(want.Edges[kind])
+		}
+		nodes = append(nodes, want)
+	}
+	if err := validateWorkflowGraph(edges); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+func listWorkflowGraphNodes(m interface{}, workflowJobTemplateID int) ([]workflowGraphNode, error) {
+	var nodes []workflowGraphNode
+	endpoint := fmt.Sprintf("%s%d/workflow_nodes/", workflowJobTemplatesAPIEndpoint, workflowJobTemplateID)
+	err := apiGetAll(m, endpoint, map[string]string{"order_by": "id"}, func(results json.RawMessage) error {
+		var page []workflowGraphNode
+		if err := json.Unmarshal(results, &page); err != nil {
+			return err
+		}
+		nodes = append(nodes, page...)
+		return nil
+	})
+	return nodes, err
+}
+
+func createWorkflowGraphNode(m interface{}, workflowJobTemplateID int, want *desiredGraphNode) (int, diag.Diagnostics) {
+	data := map[string]interface{}{
+		"workflow_job_template": workflowJobTemplateID,
+		"unified_job_template":  nil,
+	}
+	for key, value := range want.Fields {
+		data[key] = value
+	}
+	if want.Approval == nil {
+		data["unified_job_template"] = want.TemplateID
+	}
+
+	result := new(workflowGraphNode)
+	if err := apiPost(m, workflowJobTemplateNodesAPIEndpoint, data, result); err != nil {
+		log.Printf("Fail to create WorkflowJobTemplateNode %v", err)
+		return 0, buildDiagAPIFail(
+			"Unable to create WorkflowJobTemplateNode", err, nil,
+			"WorkflowJobTemplateNode %s of WorkflowJobTemplate with id %d faild to create %s", want.Identifier, workflowJobTemplateID, err.Error(),
+		)
+	}
+	if want.Approval != nil {
+		if diags := saveWorkflowGraphApproval(m, result.ID, 0, want); diags.HasError() {
+			return result.ID, diags
+		}
+	}
+	for key, ids := range want.Associations {
+		if len(ids) == 0 {
+			continue
+		}
+		if diags := syncWorkflowNodeAssociation(m, result.ID, key, ids); diags.HasError() {
+			return result.ID, diags
+		}
+	}
+	return result.ID, nil
+}
+
+// updateWorkflowGraphNode patches a node whose target or prompts changed.
+func updateWorkflowGraphNode(m interface{}, want *desiredGraphNode, current *workflowGraphNode) diag.Diagnostics {
+	isApproval := current.SummaryFields.UnifiedJobTemplate.UnifiedJobType == "workflow_approval"
+	data := map[string]interface{}{}
+	if want.Approval == nil && current.UnifiedJobTemplate != want.TemplateID {
+		data["unified_job_template"] = want.TemplateID
+	}
+	if current.AllParentsMustConverge != want.Fields["all_parents_must_converge"] {
+		data["all_parents_must_converge"] = want.Fields["all_parents_must_converge"]
+	}
+	liveFields := map[string]interface{}{
+		"inventory":             nilIfZero(current.Inventory),
+		"execution_environment": nilIfZero(current.ExecutionEnvironment),
+		"limit":                 nilIfZero(current.Limit),
+		"scm_branch":            nilIfZero(current.ScmBranch),
+		"job_type":              nilIfZero(current.JobType),
+		"job_tags":              nilIfZero(current.JobTags),
+		"skip_tags":             nilIfZero(current.SkipTags),
+		"extra_data":            current.ExtraData,
+		"diff_mode":             nil,
+	}
+	if liveFields["extra_data"] == nil || len(current.ExtraData) == 0 {
+		liveFields["extra_data"] = map[string]interface{}{}
+	}
+	if current.DiffMode != nil {
+		liveFields["diff_mode"] = *current.DiffMode
+	}
+	for key, value := range map[string]*int{"verbosity": current.Verbosity, "forks": current.Forks, "timeout": current.Timeout, "job_slice_count": current.JobSliceCount} {
+		liveFields[key] = nil
+		if value != nil {
+			liveFields[key] = *value
+		}
+	}
+	for _, key := range workflowNodePrompts {
+		value, ok := want.Fields[key]
+		if !ok {
+			continue
+		}
+		if !reflect.DeepEqual(value, liveFields[key]) {
+			data[key] = value
+		}
+	}
+
+	if len(data) > 0 {
+		log.Printf("[INFO] updating node %s, changed %v", want.Identifier, data)
+		if err := apiPatch(m, fmt.Sprintf("%s%d/", workflowJobTemplateNodesAPIEndpoint, current.ID), data, nil); err != nil {
+			return buildDiagAPIFail(
+				"Unable to update WorkflowJobTemplateNode", err, nil,
+				"WorkflowJobTemplateNode %s with id %d faild to update %s", want.Identifier, current.ID, err.Error(),
+			)
+		}
+	}
+	for key, ids := range want.Associations {
+		if diags := syncWorkflowNodeAssociation(m, current.ID, key, ids); diags.HasError() {
+			return diags
+		}
+	}
+	if want.Approval == nil {
+		return nil
+	}
+	if !isApproval {
+		return saveWorkflowGraphApproval(m, current.ID, 0, want)
+	}
+
+	approval := new(workflowApprovalTemplate)
+	if err := apiGet(m, fmt.Sprintf("%s%d/", workflowApprovalTemplatesAPIEndpoint, current.UnifiedJobTemplate), approval, nil); err != nil {
+		return buildDiagNotFoundFail("workflow approval template", current.UnifiedJobTemplate, err)
+	}
+	approval.ID = 0
+	if *approval == *want.Approval {
+		return nil
+	}
+	return saveWorkflowGraphApproval(m, current.ID, current.UnifiedJobTemplate, want)
+}
+
+// saveWorkflowGraphApproval creates the approval template of node id, or
+// updates the approval template approvalID.
+func saveWorkflowGraphApproval(m interface{}, id int, approvalID int, want *desiredGraphNode) diag.Diagnostics {
+	data := map[string]interface{}{
+		"name":        want.Approval.Name,
+		"description": want.Approval.Description,
+		"timeout":     want.Approval.Timeout,
+	}
+	var err error
+	if approvalID != 0 {
+		err = apiPatch(m, fmt.Sprintf("%s%d/", workflowApprovalTemplatesAPIEndpoint, approvalID), data, nil)
+	} else {
+		err = apiPost(m, fmt.Sprintf("%s%d/create_approval_template/", workflowJobTemplateNodesAPIEndpoint, id), data, nil)
+	}
+	if err != nil {
+		log.Printf("Fail to save WorkflowApprovalTemplate %v", err)
+		return buildDiagAPIFail(
+			"Unable to save WorkflowApprovalTemplate", err, nil,
+			"WorkflowApprovalTemplate of node %s with id %d faild to save %s", want.Identifier, id, err.Error(),
+		)
+	}
+	return nil
+}
+
+// linkWorkflowGraphNodes adds or, with disassociate, removes the edge of kind
+// from node parentID to node childID.
+func linkWorkflowGraphNodes(m interface{}, parentID int, kind string, childID int, parent, child string, disassociate bool) diag.Diagnostics {
+	data := map[string]interface{}{"id": childID}
+	action := "link"
+	if disassociate {
+		data["disassociate"] = true
+		action = "unlink"
+	}
+	endpoint := fmt.Sprintf("%s%d/%s/", workflowJobTemplateNodesAPIEndpoint, parentID, workflowGraphEdges[kind])
+	if err := apiPost(m, endpoint, data, nil); err != nil {
+		log.Printf("Fail to %s WorkflowJobTemplateNodes %v", action, err)
+		return buildDiagAPIFail(
+			"Unable to "+action+" WorkflowJobTemplateNodes", err, nil,
+			"%s edge from node %s to node %s faild to %s %s", kind, parent, child, action, err.Error(),
+		)
+	}
+	return nil
+}
+
+func flattenWorkflowGraphNode(m interface{}, r *workflowGraphNode, identifiers map[int]string) (map[string]interface{}, error) {
+	unifiedJobType := r.SummaryFields.UnifiedJobTemplate.UnifiedJobType
+	keys := workflowNodeTargetKeys[unifiedJobType]
+	node := map[string]interface{}{
+		"identifier":                      r.Identifier,
+		"unified_job_template_id":         r.UnifiedJobTemplate,
+		"project_id":                      0,
+		"inventory_source_id":             0,
+		"nested_workflow_job_template_id": 0,
+		"approval":                        []interface{}{},
+		"inventory_id":                    r.Inventory,
+		"execution_environment_id":        r.ExecutionEnvironment,
+		"limit":                           r.Limit,
+		"scm_branch":                      r.ScmBranch,
+		"job_type":                        r.JobType,
+		"job_tags":                        r.JobTags,
+		"skip_tags":                       r.SkipTags,
+		"diff_mode":                       r.DiffMode != nil && *r.DiffMode,
+		"extra_data":                      "",
+		"all_parents_must_converge":       r.AllParentsMustConverge,
+		"node_type":                       keys[1],
+	}
+	if keys[0] != "" {
+		node[keys[0]] = r.UnifiedJobTemplate
+	}
+	if len(r.ExtraData) > 0 {
+		b, _ := json.Marshal(r.ExtraData)
+		node["extra_data"] = string(b)
+	}
+	for key, value := range map[string]*int{"verbosity": r.Verbosity, "forks": r.Forks, "timeout": r.Timeout, "job_slice_count": r.JobSliceCount} {
+		node[key] = 0
+		if value != nil {
+			node[key] = *value
+		}
+	}
+	for key, relation := range workflowNodeAssociations {
+		ids := []int{}
+		if workflowNodeAcceptsRelation(keys[1], relation) {
+			var err error
+			if ids, err = listWorkflowNodeAssociation(m, r.ID, relation); err != nil {
+				return nil, err
+			}
+		}
+		node[key] = ids
+	}
+	for kind := range workflowGraphEdges {
+		children := []interface{}{}
+		for _, childID := range r.edges(kind) {
+			children = append(children, identifiers[childID])
+		}
+		node[kind] = children
+	}
+
+	if unifiedJobType == "workflow_approval" {
+		approval := new(workflowApprovalTemplate)
+		if err := apiGet(m, fmt.Sprintf("%s%d/", workflowApprovalTemplatesAPIEndpoint, r.UnifiedJobTemplate), approval, nil); err != nil {
+			return nil, err
+		}
+		node["approval"] = []interface{}{map[string]interface{}{
+			"name":        approval.Name,
+			"description": approval.Description,
+			"timeout":     approval.Timeout,
+		}}
+	}
+	return node, nil
+}
+
+func nilIfZero(v interface{}) interface{} {
+	if v == reflect.Zero(reflect.TypeOf(v)).Interface() {
+		return nil
+	}
+	return v
+}
+
+func containsString(items []string, item string) bool {
+	for _, v := range items {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(items []int, item int) bool {
+	for _, v := range items {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package awx
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestValidateWorkflowGraph(t *testing.T) {
+	cases := []struct {
+		name  string
+		edges map[string][]string
+		err   string
+	}{
+		{
+			name:  "empty",
+			edges: map[string][]string{},
+		},
+		{
+			name: "chain",
+			edges: map[string][]string{
+				"a": {"b"},
+				"b": {"c"},
+				"c": nil,
+			},
+		},
+		{
+			name: "multiple parents",
+			edges: map[string][]string{
+				"a": {"b", "c"},
+				"b": {"d"},
+				"c": {"d"},
+				"d": nil,
+			},
+		},
+		{
+			name: "multiple roots",
+			edges: map[string][]string{
+				"a": {"c"},
+				"b": {"c"},
+				"c": nil,
+			},
+		},
+		{
+			name: "unknown child",
+			edges: map[string][]string{
+				"a": {"b"},
+			},
+			err: `node "a" links to the unknown node "b"`,
+		},
+		{
+			name: "self loop",
+			edges: map[string][]string{
+				"a": {"a"},
+			},
+			err: "the workflow has a cycle: a -> a",
+		},
+		{
+			name: "cycle",
+			edges: map[string][]string{
+				"a": {"b"},
+				"b": {"c"},
+				"c": {"a"},
+			},
+			err: "the workflow has a cycle: a -> b -> c -> a",
+		},
+		{
+			name: "cycle below a root",
+			edges: map[string][]string{
+				"a": {"b"},
+				"b": {"c"},
+				"c": {"b"},
+			},
+			err: "the workflow has a cycle: b -> c -> b",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateWorkflowGraph(c.edges)
+			if c.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Fatalf("expected error containing %q, got %v", c.err, err)
+			}
+		})
+	}
+}
+
+func TestExpandWorkflowGraphNodes(t *testing.T) {
+	cases := []struct {
+		name   string
+		node   map[string]interface{}
+		fields map[string]interface{}
+		ids    map[string][]int
+		err    string
+	}{
+		{
+			name:   "converges by default",
+			node:   map[string]interface{}{"identifier": "sync", "project_id": 7},
+			fields: map[string]interface{}{"all_parents_must_converge": true},
+		},
+		{
+			name: "job template prompts",
+			node: map[string]interface{}{
+				"identifier": "build", "unified_job_template_id": 8, "extra_data": "a: 1", "job_type": "check",
+				"verbosity": 2, "credential_ids": []interface{}{3, 4},
+			},
+			fields: map[string]interface{}{"extra_data": map[string]interface{}{"a": 1}, "job_type": "check", "verbosity": 2, "forks": nil},
+			ids:    map[string][]int{"credential_ids": {3, 4}, "label_ids": {}, "instance_group_ids": {}},
+		},
+		{
+			name:   "nested workflow drops job only prompts",
+			node:   map[string]interface{}{"identifier": "deploy", "nested_workflow_job_template_id": 9, "limit": "web"},
+			fields: map[string]interface{}{"limit": "web", "job_type": "<unset>", "verbosity": "<unset>"},
+		},
+		{
+			name: "prompt on a project",
+			node: map[string]interface{}{"identifier": "sync", "project_id": 7, "credential_ids": []interface{}{3}},
+			err:  `node "sync": credential_ids can't be set on project nodes`,
+		},
+		{
+			name: "invalid extra_data",
+			node: map[string]interface{}{"identifier": "build", "unified_job_template_id": 8, "extra_data": "[1"},
+			err:  `node "build":`,
+		},
+		{
+			name: "two targets",
+			node: map[string]interface{}{"identifier": "sync", "project_id": 7, "unified_job_template_id": 8},
+			err:  `node "sync" needs exactly one of`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceWorkflowJobTemplateGraph().Schema, map[string]interface{}{
+				"workflow_job_template_id": 5,
+				"node":                     []interface{}{c.node},
+			})
+			nodes, err := expandWorkflowGraphNodes(d)
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Fatalf("expected error containing %q, got %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for key, value := range c.fields {
+				got, ok := nodes[0].Fields[key]
+				if value == "<unset>" {
+					if ok {
+						t.Fatalf("expected %s to be unset, got %v", key, got)
+					}
+					continue
+				}
+				if !reflect.DeepEqual(got, value) {
+					t.Fatalf("expected %s %#v, got %#v", key, value, got)
+				}
+			}
+			for key, ids := range c.ids {
+				if !reflect.DeepEqual(nodes[0].Associations[key], ids) {
+					t.Fatalf("expected %s %v, got %v", key, ids, nodes[0].Associations[key])
+				}
+			}
+		})
+	}
+}
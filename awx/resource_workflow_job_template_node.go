@@ -1,5 +1,8 @@
 /*
-*TBD*
+Adds a node to a workflow job template. A node runs exactly one of a
+job template, a project update, an inventory sync, a nested workflow or an
+approval. Every prompt AWX accepts on a node can be set, including the
+prompted credentials, labels and instance groups.
 
 Example Usage
 
@@ -11,6 +14,19 @@ resource "awx_workflow_job_template_node" "default" {
   unified_job_template_id  = awx_job_template.baseconfig.id
   inventory_id             = awx_inventory.default.id
   identifier               = random_uuid.workflow_node_base_uuid.result
+  limit                    = "webservers"
+  credential_ids           = [awx_credential_machine.deploy.id]
+  instance_group_ids       = [2, 1]
+}
+
+resource "awx_workflow_job_template_node_success" "gate" {
+  workflow_job_template_node_id = awx_workflow_job_template_node.default.id
+  identifier                    = "release-gate"
+
+  approval {
+    name    = "Release"
+    timeout = 3600
+  }
 }
 ```
 
@@ -29,21 +45,17 @@ import (
 )
 
 func resourceWorkflowJobTemplateNode() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceWorkflowJobTemplateNodeCreate,
 		ReadContext:   resourceWorkflowJobTemplateNodeRead,
 		UpdateContext: resourceWorkflowJobTemplateNodeUpdate,
 		DeleteContext: resourceWorkflowJobTemplateNodeDelete,
+		CustomizeDiff: resourceWorkflowNodeCustomizeDiff,
+		SchemaVersion: 1,
 
-		Schema: map[string]*schema.Schema{
+		Schema: addWorkflowNodePromptSchema(addWorkflowNodeTargetSchema(map[string]*schema.Schema{
 
-			"extra_data": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "",
-				StateFunc:   normalizeJsonYaml,
-			},
+			"extra_data": workflowNodeExtraDataSchema(),
 			"inventory_id": &schema.Schema{
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -84,10 +96,6 @@ func resourceWorkflowJobTemplateNode() *schema.Resource {
 				Type:     schema.TypeInt,
 				Required: true,
 			},
-			"unified_job_template_id": &schema.Schema{
-				Type:     schema.TypeInt,
-				Required: true,
-			},
 			//"success_nodes": &schema.Schema{
 			//	Type: schema.TypeList,
 			//	Elem: &schema.Schema{
@@ -119,7 +127,7 @@ func resourceWorkflowJobTemplateNode() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
-		},
+		})),
 		//Importer: &schema.ResourceImporter{
 		//	State: schema.ImportStatePassthrough,
 		//},
@@ -130,43 +138,45 @@ func resourceWorkflowJobTemplateNode() *schema.Resource {
 		//	Delete: schema.DefaultTimeout(1 * time.Minute),
 		//},
 	}
+	r.StateUpgraders = workflowNodeStateUpgraders(r.Schema)
+	return r
 }
 
 func resourceWorkflowJobTemplateNodeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
 	awxService := client.WorkflowJobTemplateNodeService
 
-	result, err := awxService.CreateWorkflowJobTemplateNode(map[string]interface{}{
-		"extra_data":            d.Get("extra_data").(string),
-		"inventory":             d.Get("inventory_id").(int),
-		"scm_branch":            d.Get("scm_branch").(string),
-		"skip_tags":             d.Get("skip_tags").(string),
-		"job_type":              d.Get("job_type").(string),
-		"job_tags":              d.Get("job_tags").(string),
-		"limit":                 d.Get("limit").(string),
-		"diff_mode":             d.Get("diff_mode").(bool),
-		"verbosity":             d.Get("verbosity").(int),
-		"workflow_job_template": d.Get("workflow_job_template_id").(int),
-		"unified_job_template":  d.Get("unified_job_template_id").(int),
-		//"failure_nodes":         d.Get("failure_nodes").([]interface{}),
-		//"success_nodes":         d.Get("success_nodes").([]interface{}),
-		//"always_nodes":          d.Get("always_nodes").([]interface{}),
+	data, err := expandWorkflowNodePrompts(d)
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"Create: Invalid extra_data",
+			"WorkflowJobTemplateNode %s has invalid extra_data: %s", d.Get("identifier").(string), err.Error(),
+		)
+	}
+	data["workflow_job_template"] = d.Get("workflow_job_template_id").(int)
+	//data["failure_nodes"] = d.Get("failure_nodes").([]interface{})
+	//data["success_nodes"] = d.Get("success_nodes").([]interface{})
+	//data["always_nodes"] = d.Get("always_nodes").([]interface{})
+	data["all_parents_must_converge"] = d.Get("all_parents_must_converge").(bool)
+	data["identifier"] = d.Get("identifier").(string)
 
-		"all_parents_must_converge": d.Get("all_parents_must_converge").(bool),
-		"identifier":                d.Get("identifier").(string),
-	}, map[string]string{})
+	expandWorkflowNodeTarget(d, data, true)
+	result, err := awxService.CreateWorkflowJobTemplateNode(data, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Template %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create WorkflowJobTemplateNode",
-			Detail:   fmt.Sprintf("WorkflowJobTemplateNode with JobTemplateID %d and WorkflowID: %d faild to create %s", d.Get("unified_job_template_id").(int), d.Get("workflow_job_template_id").(int), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail(
+			"Unable to create WorkflowJobTemplateNode", err, workflowNodeFieldAttributes,
+			"WorkflowJobTemplateNode with JobTemplateID %v and WorkflowID: %d faild to create %s", data["unified_job_template"], d.Get("workflow_job_template_id").(int), err.Error(),
+		)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
+	if diags := updateWorkflowNodeApproval(d, m, result.ID, nil); diags.HasError() {
+		return diags
+	}
+	if diags := updateWorkflowNodeAssociations(d, m, result.ID, true); diags.HasError() {
+		return diags
+	}
 	return resourceWorkflowJobTemplateNodeRead(ctx, d, m)
 }
 
@@ -179,36 +189,38 @@ func resourceWorkflowJobTemplateNodeUpdate(ctx context.Context, d *schema.Resour
 		return diags
 	}
 
-	params := make(map[string]string)
-	_, err := awxService.GetWorkflowJobTemplateNodeByID(id, params)
+	current, err := getWorkflowJobTemplateNode(m, id)
 	if err != nil {
 		return buildDiagNotFoundFail("workflow job template node", id, err)
 	}
 
-	_, err = awxService.UpdateWorkflowJobTemplateNode(id, map[string]interface{}{
-		"extra_data":            d.Get("extra_data").(string),
-		"inventory":             d.Get("inventory_id").(int),
-		"scm_branch":            d.Get("scm_branch").(string),
-		"skip_tags":             d.Get("skip_tags").(string),
-		"job_type":              d.Get("job_type").(string),
-		"job_tags":              d.Get("job_tags").(string),
-		"limit":                 d.Get("limit").(string),
-		"diff_mode":             d.Get("diff_mode").(bool),
-		"verbosity":             d.Get("verbosity").(int),
-		"workflow_job_template": d.Get("workflow_job_template_id").(int),
-		"unified_job_template":  d.Get("unified_job_template_id").(int),
-		//"failure_nodes":             d.Get("failure_nodes").([]interface{}),
-		//"success_nodes":             d.Get("success_nodes").([]interface{}),
-		//"always_nodes":              d.Get("always_nodes").([]interface{}),
-		"all_parents_must_converge": d.Get("all_parents_must_converge").(bool),
-		"identifier":                d.Get("identifier").(string),
-	}, map[string]string{})
+	data, err := expandWorkflowNodePrompts(d)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update WorkflowJobTemplateNode",
-			Detail:   fmt.Sprintf("WorkflowJobTemplateNode with name %s in the project id %d faild to update %s", d.Get("name").(string), d.Get("project_id").(int), err.Error()),
-		})
+		return buildDiagnosticsMessage(
+			"Update: Invalid extra_data",
+			"WorkflowJobTemplateNode with id %d has invalid extra_data: %s", id, err.Error(),
+		)
+	}
+	// The success, failure and always nodes share the update without a
+	// workflow_job_template_id.
+	if v, ok := d.GetOk("workflow_job_template_id"); ok {
+		data["workflow_job_template"] = v.(int)
+	}
+	data["all_parents_must_converge"] = d.Get("all_parents_must_converge").(bool)
+	data["identifier"] = d.Get("identifier").(string)
+
+	expandWorkflowNodeTarget(d, data, false)
+	_, err = awxService.UpdateWorkflowJobTemplateNode(id, data, map[string]string{})
+	if err != nil {
+		return buildDiagAPIFail(
+			"Unable to update WorkflowJobTemplateNode", err, workflowNodeFieldAttributes,
+			"WorkflowJobTemplateNode with id %d faild to update %s", id, err.Error(),
+		)
+	}
+	if diags := updateWorkflowNodeApproval(d, m, id, &current.WorkflowJobTemplateNode); diags.HasError() {
+		return diags
+	}
+	if diags := updateWorkflowNodeAssociations(d, m, id, false); diags.HasError() {
 		return diags
 	}
 
@@ -216,20 +228,26 @@ func resourceWorkflowJobTemplateNodeUpdate(ctx context.Context, d *schema.Resour
 }
 
 func resourceWorkflowJobTemplateNodeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	client := m.(*awx.AWX)
-	awxService := client.WorkflowJobTemplateNodeService
 	id, diags := convertStateIDToNummeric("Read WorkflowJobTemplateNode", d)
 	if diags.HasError() {
 		return diags
 	}
 
-	res, err := awxService.GetWorkflowJobTemplateNodeByID(id, make(map[string]string))
+	res, err := getWorkflowJobTemplateNode(m, id)
 	if err != nil {
+		if isNotFound(err) {
+			return removeResourceFromState(d, "workflow job template node", id)
+		}
 		return buildDiagNotFoundFail("workflow job template node", id, err)
 
 	}
-	d = setWorkflowJobTemplateNodeResourceData(d, res)
+	d = setWorkflowJobTemplateNodeResourceData(d, &res.WorkflowJobTemplateNode)
+	if err := setWorkflowNodeTargetResourceData(d, m, &res.WorkflowJobTemplateNode); err != nil {
+		return buildDiagNotFoundFail("workflow approval template", res.UnifiedJobTemplate, err)
+	}
+	if err := setWorkflowNodePromptResourceData(d, m, res); err != nil {
+		return buildDiagNotFoundFail("workflow job template node prompts", id, err)
+	}
 	return nil
 }
 
@@ -253,21 +271,18 @@ func resourceWorkflowJobTemplateNodeDelete(ctx context.Context, d *schema.Resour
 
 func setWorkflowJobTemplateNodeResourceData(d *schema.ResourceData, r *awx.WorkflowJobTemplateNode) *schema.ResourceData {
 
-	d.Set("extra_data", normalizeJsonYaml(r.ExtraData))
-	d.Set("inventory_id", strconv.Itoa(r.Inventory))
+	d.Set("inventory_id", r.Inventory)
 	d.Set("scm_branch", r.ScmBranch)
 	d.Set("job_type", r.JobType)
 	d.Set("job_tags", r.JobTags)
 	d.Set("skip_tags", r.SkipTags)
 	d.Set("limit", r.Limit)
-	d.Set("diff_mode", r.DiffMode)
 	d.Set("verbosity", r.Verbosity)
 	//d.Set("failure_nodes", r.FailureNodes)
 	//d.Set("success_nodes", r.SuccessNodes)
 	//d.Set("always_nodes", r.AlwaysNodes)
 
-	d.Set("workflow_job_template_id", strconv.Itoa(r.WorkflowJobTemplate))
-	d.Set("unified_job_template_id", strconv.Itoa(r.UnifiedJobTemplate))
+	d.Set("workflow_job_template_id", r.WorkflowJobTemplate)
 	d.Set("all_parents_must_converge", r.AllParentsMustConverge)
 	d.Set("identifier", r.Identifier)
 
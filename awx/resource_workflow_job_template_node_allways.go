@@ -27,11 +27,14 @@ import (
 
 func resourceWorkflowJobTemplateNodeAllways() *schema.Resource {
 	return &schema.Resource{
-		CreateContext: resourceWorkflowJobTemplateNodeAllwaysCreate,
-		ReadContext:   resourceWorkflowJobTemplateNodeRead,
-		UpdateContext: resourceWorkflowJobTemplateNodeUpdate,
-		DeleteContext: resourceWorkflowJobTemplateNodeDelete,
-		Schema:        workflowJobNodeSchema,
+		CreateContext:  resourceWorkflowJobTemplateNodeAllwaysCreate,
+		ReadContext:    resourceWorkflowJobTemplateNodeRead,
+		UpdateContext:  resourceWorkflowJobTemplateNodeUpdate,
+		DeleteContext:  resourceWorkflowJobTemplateNodeDelete,
+		CustomizeDiff:  resourceWorkflowNodeCustomizeDiff,
+		Schema:         workflowJobNodeSchema,
+		SchemaVersion:  1,
+		StateUpgraders: workflowNodeStateUpgraders(workflowJobNodeSchema),
 	}
 }
 func resourceWorkflowJobTemplateNodeAllwaysCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -27,11 +27,14 @@ import (
 
 func resourceWorkflowJobTemplateNodeFailure() *schema.Resource {
 	return &schema.Resource{
-		CreateContext: resourceWorkflowJobTemplateNodeFailureCreate,
-		ReadContext:   resourceWorkflowJobTemplateNodeRead,
-		UpdateContext: resourceWorkflowJobTemplateNodeUpdate,
-		DeleteContext: resourceWorkflowJobTemplateNodeDelete,
-		Schema:        workflowJobNodeSchema,
+		CreateContext:  resourceWorkflowJobTemplateNodeFailureCreate,
+		ReadContext:    resourceWorkflowJobTemplateNodeRead,
+		UpdateContext:  resourceWorkflowJobTemplateNodeUpdate,
+		DeleteContext:  resourceWorkflowJobTemplateNodeDelete,
+		CustomizeDiff:  resourceWorkflowNodeCustomizeDiff,
+		Schema:         workflowJobNodeSchema,
+		SchemaVersion:  1,
+		StateUpgraders: workflowNodeStateUpgraders(workflowJobNodeSchema),
 	}
 }
 
@@ -27,11 +27,14 @@ import (
 
 func resourceWorkflowJobTemplateNodeSuccess() *schema.Resource {
 	return &schema.Resource{
-		CreateContext: resourceWorkflowJobTemplateNodeSuccessCreate,
-		ReadContext:   resourceWorkflowJobTemplateNodeRead,
-		UpdateContext: resourceWorkflowJobTemplateNodeUpdate,
-		DeleteContext: resourceWorkflowJobTemplateNodeDelete,
-		Schema:        workflowJobNodeSchema,
+		CreateContext:  resourceWorkflowJobTemplateNodeSuccessCreate,
+		ReadContext:    resourceWorkflowJobTemplateNodeRead,
+		UpdateContext:  resourceWorkflowJobTemplateNodeUpdate,
+		DeleteContext:  resourceWorkflowJobTemplateNodeDelete,
+		CustomizeDiff:  resourceWorkflowNodeCustomizeDiff,
+		Schema:         workflowJobNodeSchema,
+		SchemaVersion:  1,
+		StateUpgraders: workflowNodeStateUpgraders(workflowJobNodeSchema),
 	}
 }
 
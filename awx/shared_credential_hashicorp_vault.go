@@ -0,0 +1,125 @@
+package awx
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// hashicorpVaultAuthMethods are the arguments selecting how AWX authenticates
+// to Vault, AWX uses the first one it finds so exactly one may be set.
+var hashicorpVaultAuthMethods = []string{"token", "role_id", "kubernetes_role", "client_cert_public"}
+
+// hashicorpVaultOptionalInputs are only sent when set, older AWX versions
+// don't declare all of them.
+var hashicorpVaultOptionalInputs = []string{
+	"namespace",
+	"role_id",
+	"secret_id",
+	"default_auth_path",
+	"kubernetes_role",
+	"client_cert_public",
+	"client_cert_private",
+	"client_cert_role",
+}
+
+// addHashicorpVaultSchema adds the arguments shared by the HashiCorp Vault
+// credential types to s.
+func addHashicorpVaultSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["url"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+		Description:  "URL of the Vault server",
+	}
+	s["cacert"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "PEM encoded CA certificate to verify the Vault server with",
+	}
+	s["namespace"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Vault Enterprise namespace",
+	}
+	s["token"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		ExactlyOneOf: hashicorpVaultAuthMethods,
+		Description:  "Token to authenticate with",
+	}
+	s["role_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ExactlyOneOf: hashicorpVaultAuthMethods,
+		RequiredWith: []string{"secret_id"},
+		Description:  "Role ID of the AppRole to authenticate with",
+	}
+	s["secret_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		RequiredWith: []string{"role_id"},
+		Description:  "Secret ID of the AppRole",
+	}
+	s["kubernetes_role"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ExactlyOneOf: hashicorpVaultAuthMethods,
+		Description:  "Role to authenticate with the service account token of the AWX pod",
+	}
+	s["client_cert_public"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ExactlyOneOf: hashicorpVaultAuthMethods,
+		RequiredWith: []string{"client_cert_private"},
+		Description:  "PEM encoded client certificate to authenticate with",
+	}
+	s["client_cert_private"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		RequiredWith: []string{"client_cert_public"},
+		Description:  "PEM encoded private key of the client certificate",
+	}
+	s["client_cert_role"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		RequiredWith: []string{"client_cert_public"},
+		Description:  "Certificate role to authenticate with, all roles are tried if unset",
+	}
+	s["default_auth_path"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Mount path of the auth method, input sources can override it with auth_path",
+	}
+	return s
+}
+
+func expandHashicorpVaultInputs(d *schema.ResourceData) map[string]interface{} {
+	inputs := map[string]interface{}{
+		"url":    d.Get("url").(string),
+		"cacert": d.Get("cacert").(string),
+		"token":  d.Get("token").(string),
+	}
+	for _, key := range hashicorpVaultOptionalInputs {
+		if v := d.Get(key).(string); v != "" {
+			inputs[key] = v
+		}
+	}
+	return inputs
+}
+
+func setHashicorpVaultResourceData(d *schema.ResourceData, inputs map[string]interface{}) {
+	d.Set("url", inputs["url"])
+	d.Set("cacert", inputs["cacert"])
+	d.Set("namespace", inputs["namespace"])
+	setCredentialSecret(d, "token", inputs["token"])
+	d.Set("role_id", inputs["role_id"])
+	setCredentialSecret(d, "secret_id", inputs["secret_id"])
+	d.Set("kubernetes_role", inputs["kubernetes_role"])
+	d.Set("client_cert_public", inputs["client_cert_public"])
+	setCredentialSecret(d, "client_cert_private", inputs["client_cert_private"])
+	d.Set("client_cert_role", inputs["client_cert_role"])
+	d.Set("default_auth_path", inputs["default_auth_path"])
+}
@@ -0,0 +1,199 @@
+package awx
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+const (
+	credentialsAPIEndpoint     = "/api/v2/credentials/"
+	credentialTypesAPIEndpoint = "/api/v2/credential_types/"
+)
+
+// encryptedInput is what AWX returns in place of secret credential inputs.
+const encryptedInput = "$encrypted$"
+
+type credentialType struct {
+	ID          int                     `json:"id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Kind        string                  `json:"kind"`
+	Namespace   string                  `json:"namespace"`
+	Managed     bool                    `json:"managed"`
+	Inputs      credentialTypeInputs    `json:"inputs"`
+	Injectors   credentialTypeInjectors `json:"injectors"`
+}
+
+type credentialTypeInputs struct {
+	Fields   []credentialTypeField `json:"fields"`
+	Metadata []credentialTypeField `json:"metadata,omitempty"`
+	Required []string              `json:"required,omitempty"`
+}
+
+type credentialTypeField struct {
+	ID        string   `json:"id"`
+	Label     string   `json:"label"`
+	Type      string   `json:"type"`
+	Secret    bool     `json:"secret,omitempty"`
+	Multiline bool     `json:"multiline,omitempty"`
+	HelpText  string   `json:"help_text,omitempty"`
+	Format    string   `json:"format,omitempty"`
+	Choices   []string `json:"choices,omitempty"`
+}
+
+type credentialTypeInjectors struct {
+	Env       map[string]string      `json:"env,omitempty"`
+	ExtraVars map[string]interface{} `json:"extra_vars,omitempty"`
+	File      map[string]string      `json:"file,omitempty"`
+}
+
+// findCredentialType returns the credential type matching every non empty
+// value of params, it fails unless exactly one type matches.
+func findCredentialType(m interface{}, params map[string]string) (*credentialType, error) {
+	query := map[string]string{}
+	for key, value := range params {
+		if value != "" {
+			query[key] = value
+		}
+	}
+	page := new(apiPage)
+	if err := apiGet(m, credentialTypesAPIEndpoint, page, query); err != nil {
+		return nil, err
+	}
+	var types []credentialType
+	if err := json.Unmarshal(page.Results, &types); err != nil {
+		return nil, err
+	}
+	if len(types) != 1 {
+		return nil, fmt.Errorf("%d credential types match %v", len(types), query)
+	}
+	return &types[0], nil
+}
+
+func getCredentialType(m interface{}, id int) (*credentialType, error) {
+	result := new(credentialType)
+	if err := apiGet(m, fmt.Sprintf("%s%d/", credentialTypesAPIEndpoint, id), result, nil); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// managedCredentialTypes holds the managed credential types already looked up,
+// keyed by managedCredentialTypeKey. AWX ships them with the install, they
+// don't change while the provider runs.
+var managedCredentialTypes sync.Map
+
+type managedCredentialTypeKey struct {
+	client    *awx.AWX
+	namespace string
+}
+
+// managedCredentialType returns the credential type AWX ships for namespace,
+// the ids differ between installs. Each type is looked up once per provider.
+func managedCredentialType(m interface{}, namespace string) (*credentialType, diag.Diagnostics) {
+	key := managedCredentialTypeKey{client: m.(*awx.AWX), namespace: namespace}
+	if t, ok := managedCredentialTypes.Load(key); ok {
+		return t.(*credentialType), nil
+	}
+	t, err := findCredentialType(m, map[string]string{"namespace": namespace, "managed": "true"})
+	if err != nil {
+		return nil, buildDiagnosticsMessage(
+			"Unable to find CredentialType",
+			"Fail to find the credential type with namespace %s: %s", namespace, err.Error(),
+		)
+	}
+	managedCredentialTypes.Store(key, t)
+	return t, nil
+}
+
+func managedCredentialTypeID(m interface{}, namespace string) (int, diag.Diagnostics) {
+	t, diags := managedCredentialType(m, namespace)
+	if diags.HasError() {
+		return 0, diags
+	}
+	return t.ID, nil
+}
+
+// checkCredentialInputs fails for inputs t doesn't declare, older AWX
+// versions lack some inputs of the types they ship.
+func checkCredentialInputs(t *credentialType, inputs map[string]interface{}) error {
+	declared := map[string]bool{}
+	for _, field := range t.Inputs.Fields {
+		declared[field.ID] = true
+	}
+	var missing []string
+	for key := range inputs {
+		if !declared[key] {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("credential type %s of this AWX has no input %s", t.Name, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// expandCredentialInputs converts the string inputs of the configuration to
+// the types the input fields of t declare.
+func expandCredentialInputs(t *credentialType, inputs map[string]interface{}) (map[string]interface{}, error) {
+	fieldTypes := map[string]string{}
+	for _, field := range t.Inputs.Fields {
+		fieldTypes[field.ID] = field.Type
+	}
+	result := make(map[string]interface{}, len(inputs))
+	for key, value := range inputs {
+		s := value.(string)
+		if fieldTypes[key] != "boolean" {
+			result[key] = s
+			continue
+		}
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("input %s of credential type %s is a boolean, got %q", key, t.Name, s)
+		}
+		result[key] = b
+	}
+	return result, nil
+}
+
+// flattenCredentialInputs converts the inputs AWX returns to strings. AWX
+// never returns secrets, those keep the value of current.
+func flattenCredentialInputs(inputs map[string]interface{}, current map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(inputs))
+	for key, value := range inputs {
+		switch v := value.(type) {
+		case string:
+			if v == encryptedInput {
+				if s, ok := current[key]; ok {
+					result[key] = s
+					continue
+				}
+			}
+			result[key] = v
+		case nil:
+			continue
+		default:
+			b, _ := json.Marshal(v)
+			result[key] = strings.Trim(string(b), `"`)
+		}
+	}
+	return result
+}
+
+// setCredentialSecret sets the secret input value of a typed credential
+// resource, unless AWX returned it encrypted and the state keeps the secret.
+func setCredentialSecret(d *schema.ResourceData, key string, value interface{}) {
+	if value == encryptedInput {
+		return
+	}
+	d.Set(key, value)
+}
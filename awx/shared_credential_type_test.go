@@ -0,0 +1,79 @@
+package awx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+// newCredentialTypeTestClient returns a client of an AWX shipping the Network
+// credential type without authorize_password, lookups counts its requests.
+func newCredentialTypeTestClient(t *testing.T, lookups *int32) *awx.AWX {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != credentialTypesAPIEndpoint || r.URL.Query().Get("namespace") != "net" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+			return
+		}
+		atomic.AddInt32(lookups, 1)
+		w.Write([]byte(`{"count": 1, "results": [{"id": 6, "name": "Network", "namespace": "net", "managed": true, "inputs": {"fields": [
+			{"id": "username", "type": "string"}, {"id": "password", "type": "string", "secret": true},
+			{"id": "ssh_key_data", "type": "string"}, {"id": "ssh_key_unlock", "type": "string"},
+			{"id": "authorize", "type": "boolean"}
+		]}}]}`))
+	}))
+	t.Cleanup(srv.Close)
+	client := &awx.AWX{}
+	registerRequester(client, &awx.Requester{Base: srv.URL, Client: srv.Client()})
+	return client
+}
+
+func TestManagedCredentialTypeIsLookedUpOnce(t *testing.T) {
+	var lookups int32
+	client := newCredentialTypeTestClient(t, &lookups)
+	for i := 0; i < 3; i++ {
+		networkType, diags := managedCredentialType(client, "net")
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if networkType.ID != 6 {
+			t.Fatalf("expected credential type 6, got %d", networkType.ID)
+		}
+	}
+	if lookups := atomic.LoadInt32(&lookups); lookups != 1 {
+		t.Fatalf("expected 1 lookup, got %d", lookups)
+	}
+
+	// Another provider looks the type up on its own AWX.
+	var otherLookups int32
+	if _, diags := managedCredentialType(newCredentialTypeTestClient(t, &otherLookups), "net"); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if otherLookups := atomic.LoadInt32(&otherLookups); otherLookups != 1 {
+		t.Fatalf("expected 1 lookup of the other provider, got %d", otherLookups)
+	}
+}
+
+func TestCredentialCreateChecksInputs(t *testing.T) {
+	var lookups int32
+	client := newCredentialTypeTestClient(t, &lookups)
+	d := schema.TestResourceDataRaw(t, resourceCredentialNetwork().Schema, map[string]interface{}{
+		"name":               "switches",
+		"organisation_id":    1,
+		"username":           "admin",
+		"authorize":          true,
+		"authorize_password": "enable",
+	})
+
+	diags := resourceCredentialNetworkCreate(context.Background(), d, client)
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail, "credential type Network of this AWX has no input authorize_password") {
+		t.Fatalf("expected the undeclared input to be rejected, got %v", diags)
+	}
+}
@@ -0,0 +1,335 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	jobTemplatesAPIEndpoint         = "/api/v2/job_templates/"
+	workflowJobTemplatesAPIEndpoint = "/api/v2/workflow_job_templates/"
+
+	// surveyEncryptedValue is returned by AWX instead of password defaults.
+	surveyEncryptedValue = "$encrypted$"
+)
+
+var surveyQuestionTypes = []string{"text", "textarea", "password", "integer", "float", "multiplechoice", "multiselect"}
+
+// surveyFieldAttributes maps the AWX errors of a survey spec to the survey block.
+var surveyFieldAttributes = map[string]string{
+	"error": "survey",
+	"spec":  "survey",
+}
+
+var surveySchema = &schema.Schema{
+	Type:        schema.TypeList,
+	Optional:    true,
+	MaxItems:    1,
+	Description: "Survey shown when the template is launched. The survey is only managed once the block is set, removing the block then deletes the survey in AWX",
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"question": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"question_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"question_description": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "",
+						},
+						"variable": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Extra variable the answer is stored in",
+						},
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(surveyQuestionTypes, false),
+							Description:  "One of: text, textarea, password, integer, float, multiplechoice, multiselect",
+						},
+						"required": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"choices": &schema.Schema{
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Choices of multiplechoice and multiselect questions",
+						},
+						"min": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Minimum length of text answers or minimum value of numeric answers",
+						},
+						"max": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Maximum length of text answers or maximum value of numeric answers",
+						},
+						"default": &schema.Schema{
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "",
+							DiffSuppressFunc: suppressEncryptedSurveyDefault,
+							Description:      "Default answer, multiselect defaults are separated by newlines. Password questions take password_default",
+						},
+						"password_default": &schema.Schema{
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "",
+							Sensitive:        true,
+							DiffSuppressFunc: suppressEncryptedSurveyDefault,
+							Description:      "Default answer of password questions",
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+// suppressEncryptedSurveyDefault ignores the placeholder AWX returns for
+// password defaults, e.g. after an import, the real value is never returned.
+func suppressEncryptedSurveyDefault(k, old, new string, d *schema.ResourceData) bool {
+	return old == surveyEncryptedValue && new != ""
+}
+
+type surveySpec struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Spec        []surveyQuestion `json:"spec"`
+}
+
+type surveyQuestion struct {
+	QuestionName        string      `json:"question_name"`
+	QuestionDescription string      `json:"question_description"`
+	Variable            string      `json:"variable"`
+	Type                string      `json:"type"`
+	Required            bool        `json:"required"`
+	Choices             interface{} `json:"choices,omitempty"`
+	Min                 interface{} `json:"min,omitempty"`
+	Max                 interface{} `json:"max,omitempty"`
+	Default             interface{} `json:"default,omitempty"`
+}
+
+func surveySpecEndpoint(templatesEndpoint string, id int) string {
+	return fmt.Sprintf("%s%d/survey_spec/", templatesEndpoint, id)
+}
+
+// updateSurveySpec replaces the survey of the template with the survey block,
+// the survey is deleted when the block is removed.
+func updateSurveySpec(d *schema.ResourceData, m interface{}, templatesEndpoint string, id int) error {
+	spec, err := expandSurveySpec(d.Get("survey").([]interface{}))
+	if err != nil {
+		return err
+	}
+	if spec == nil {
+		return apiDelete(m, surveySpecEndpoint(templatesEndpoint, id))
+	}
+	return apiPost(m, surveySpecEndpoint(templatesEndpoint, id), spec, nil)
+}
+
+// readSurveySpec refreshes the survey block. Surveys are only managed once the
+// block is set, surveys built in the UI are left alone otherwise.
+func readSurveySpec(d *schema.ResourceData, m interface{}, templatesEndpoint string, id int) error {
+	if len(d.Get("survey").([]interface{})) == 0 {
+		return nil
+	}
+	spec := new(surveySpec)
+	if err := apiGet(m, surveySpecEndpoint(templatesEndpoint, id), spec, nil); err != nil {
+		return err
+	}
+	return d.Set("survey", flattenSurveySpec(spec, d.Get("survey").([]interface{})))
+}
+
+func expandSurveySpec(survey []interface{}) (*surveySpec, error) {
+	if len(survey) == 0 || survey[0] == nil {
+		return nil, nil
+	}
+	block := survey[0].(map[string]interface{})
+	spec := &surveySpec{
+		Name:        block["name"].(string),
+		Description: block["description"].(string),
+		Spec:        []surveyQuestion{},
+	}
+	for _, item := range block["question"].([]interface{}) {
+		q := item.(map[string]interface{})
+		question := surveyQuestion{
+			QuestionName:        q["question_name"].(string),
+			QuestionDescription: q["question_description"].(string),
+			Variable:            q["variable"].(string),
+			Type:                q["type"].(string),
+			Required:            q["required"].(bool),
+		}
+		if choices := q["choices"].([]interface{}); len(choices) > 0 {
+			values := make([]string, 0, len(choices))
+			for _, choice := range choices {
+				values = append(values, choice.(string))
+			}
+			// Newline separated choices are understood by every AWX version.
+			question.Choices = strings.Join(values, "\n")
+		}
+		if min := q["min"].(int); min != 0 {
+			question.Min = min
+		}
+		if max := q["max"].(int); max != 0 {
+			question.Max = max
+		}
+		value := q["default"].(string)
+		if question.Type == "password" {
+			if value != "" {
+				return nil, fmt.Errorf("default of password survey question %s is set, use password_default", question.Variable)
+			}
+			value = q["password_default"].(string)
+		} else if q["password_default"].(string) != "" {
+			return nil, fmt.Errorf("password_default of survey question %s is set, it is only used by password questions", question.Variable)
+		}
+		if value != "" {
+			defaultValue, err := expandSurveyDefault(question.Type, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid default of survey question %s: %s", question.Variable, err)
+			}
+			question.Default = defaultValue
+		}
+		spec.Spec = append(spec.Spec, question)
+	}
+	return spec, nil
+}
+
+// expandSurveyDefault converts the default of numeric questions, AWX rejects
+// numbers sent as strings.
+func expandSurveyDefault(questionType, value string) (interface{}, error) {
+	switch questionType {
+	case "integer":
+		return strconv.Atoi(value)
+	case "float":
+		return strconv.ParseFloat(value, 64)
+	default:
+		return value, nil
+	}
+}
+
+// flattenSurveySpec converts the survey spec returned by AWX into the survey
+// block. Password defaults are returned encrypted, the value of the previous
+// state is kept for them.
+func flattenSurveySpec(spec *surveySpec, previous []interface{}) []interface{} {
+	if spec == nil || len(spec.Spec) == 0 {
+		return []interface{}{}
+	}
+
+	previousDefaults := map[string]string{}
+	if len(previous) > 0 && previous[0] != nil {
+		for _, item := range previous[0].(map[string]interface{})["question"].([]interface{}) {
+			q := item.(map[string]interface{})
+			value := q["password_default"].(string)
+			if value == "" {
+				// Older states kept password defaults in default.
+				value = q["default"].(string)
+			}
+			previousDefaults[q["variable"].(string)] = value
+		}
+	}
+
+	questions := make([]interface{}, 0, len(spec.Spec))
+	for _, question := range spec.Spec {
+		defaultValue := flattenSurveyValue(question.Default)
+		passwordDefault := ""
+		if question.Type == "password" {
+			passwordDefault, defaultValue = defaultValue, ""
+			if passwordDefault == surveyEncryptedValue {
+				if value, ok := previousDefaults[question.Variable]; ok && value != "" {
+					passwordDefault = value
+				}
+			}
+		}
+		questions = append(questions, map[string]interface{}{
+			"question_name":        question.QuestionName,
+			"question_description": question.QuestionDescription,
+			"variable":             question.Variable,
+			"type":                 question.Type,
+			"required":             question.Required,
+			"choices":              flattenSurveyChoices(question.Choices),
+			"min":                  flattenSurveyLimit(question.Min),
+			"max":                  flattenSurveyLimit(question.Max),
+			"default":              defaultValue,
+			"password_default":     passwordDefault,
+		})
+	}
+	return []interface{}{map[string]interface{}{
+		"name":        spec.Name,
+		"description": spec.Description,
+		"question":    questions,
+	}}
+}
+
+// flattenSurveyChoices reads choices sent as newline separated string, by
+// older AWX versions, or as list.
+func flattenSurveyChoices(choices interface{}) []interface{} {
+	var values []interface{}
+	switch v := choices.(type) {
+	case string:
+		for _, choice := range strings.Split(v, "\n") {
+			if choice != "" {
+				values = append(values, choice)
+			}
+		}
+	case []interface{}:
+		for _, choice := range v {
+			values = append(values, flattenSurveyValue(choice))
+		}
+	}
+	return values
+}
+
+func flattenSurveyLimit(limit interface{}) int {
+	switch v := limit.(type) {
+	case float64:
+		return int(v)
+	case string:
+		value, _ := strconv.Atoi(v)
+		return value
+	}
+	return 0
+}
+
+func flattenSurveyValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, flattenSurveyValue(item))
+		}
+		return strings.Join(values, "\n")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
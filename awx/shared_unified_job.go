@@ -0,0 +1,219 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+// unifiedJobPollInterval is the wait between two status checks of a running job.
+const unifiedJobPollInterval = 5 * time.Second
+
+// unifiedJobFinalStatuses are the statuses of jobs, workflow jobs and ad hoc
+// commands that AWX won't change anymore.
+var unifiedJobFinalStatuses = map[string]bool{
+	"successful": true,
+	"failed":     true,
+	"error":      true,
+	"canceled":   true,
+}
+
+// unifiedJob holds the fields shared by jobs, workflow jobs and ad hoc commands.
+type unifiedJob struct {
+	ID       int       `json:"id"`
+	Status   string    `json:"status"`
+	Failed   bool      `json:"failed"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	Elapsed  float64   `json:"elapsed"`
+}
+
+// waitForUnifiedJob polls the status of a job until it is final and returns the
+// final status. The deadline of ctx, usually the create timeout, bounds the wait.
+func waitForUnifiedJob(ctx context.Context, tfElement string, id int, getStatus func() (string, error)) (string, error) {
+	for {
+		status, err := getStatus()
+		if err != nil {
+			return "", err
+		}
+		if unifiedJobFinalStatuses[status] {
+			log.Printf("[INFO] %s %d finished with status %s", tfElement, id, status)
+			return status, nil
+		}
+		log.Printf("[DEBUG] %s %d is %s, checking again in %s", tfElement, id, status, unifiedJobPollInterval)
+
+		select {
+		case <-ctx.Done():
+			return status, fmt.Errorf("timed out waiting for %s %d to finish, last status %s", tfElement, id, status)
+		case <-time.After(unifiedJobPollInterval):
+		}
+	}
+}
+
+// launchedJob is a job launched by a resource, see waitForLaunchedJob.
+type launchedJob struct {
+	// kind names the job in the diagnostics, like WorkflowJob.
+	kind string
+	// endpoint is the API endpoint of the jobs of the kind.
+	endpoint string
+	id       int
+	// origin follows the job in the diagnostics, like "of JobTemplate with id 3".
+	origin string
+	// failures describes the failed parts of the job from the state, like the
+	// failed nodes of a workflow job. It can be nil.
+	failures func(d *schema.ResourceData) string
+}
+
+// waitForLaunchedJob waits for job, reads the resource into d with read and
+// reports a job that didn't succeed. The report is a warning, or an error
+// when fail_on_job_failure is set.
+func waitForLaunchedJob(ctx context.Context, d *schema.ResourceData, m interface{}, job launchedJob, read schema.ReadContextFunc) diag.Diagnostics {
+	status, err := waitForUnifiedJob(ctx, job.kind, job.id, func() (string, error) {
+		current, err := getUnifiedJob(m, job.endpoint, job.id)
+		if err != nil {
+			return "", err
+		}
+		return current.Status, nil
+	})
+	diags := read(ctx, d, m)
+	name := fmt.Sprintf("%s %d", job.kind, job.id)
+	if job.origin != "" {
+		name += " " + job.origin
+	}
+	if err != nil {
+		return append(diags, buildDiagnosticsMessage(
+			"Unable to wait for "+job.kind,
+			"%s didn't finish: %s", name, err.Error(),
+		)...)
+	}
+
+	failures := ""
+	if job.failures != nil {
+		failures = job.failures(d)
+	}
+	if status == awx.JobStatusSuccessful && failures == "" {
+		return diags
+	}
+	failure := diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  job.kind + " not successful",
+		Detail:   fmt.Sprintf("%s finished with status %s%s", name, status, failures),
+	}
+	if d.Get("fail_on_job_failure").(bool) {
+		failure.Severity = diag.Error
+	}
+	return append(diags, failure)
+}
+
+// getUnifiedJob reads the job id from endpoint, the API endpoint of its kind.
+func getUnifiedJob(m interface{}, endpoint string, id int) (*unifiedJob, error) {
+	result := new(unifiedJob)
+	if err := apiGet(m, fmt.Sprintf("%s%d/", endpoint, id), result, nil); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// getLaunchedJob reads the job a resource launched. AWX cleans up old jobs, a
+// missing job must not launch the job again, the result is nil without an error.
+func getLaunchedJob(m interface{}, tfElement string, endpoint string, id int) (*unifiedJob, diag.Diagnostics) {
+	job, err := getUnifiedJob(m, endpoint, id)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] %s %d not found in AWX, keeping it in the state", tfElement, id)
+			return nil, nil
+		}
+		return nil, buildDiagNotFoundFail(tfElement, id, err)
+	}
+	return job, nil
+}
+
+// unifiedJobSchema adds fail_on_job_failure and the computed attributes of the
+// launched job to s, kind names the job in the descriptions. Attributes s
+// already defines are kept.
+func unifiedJobSchema(kind string, s map[string]*schema.Schema) map[string]*schema.Schema {
+	shared := map[string]*schema.Schema{
+		"fail_on_job_failure": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: fmt.Sprintf("Fail the apply when the %s doesn't succeed", kind),
+		},
+		"status": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: fmt.Sprintf("Status of the %s", kind),
+		},
+		"failed": &schema.Schema{
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: fmt.Sprintf("Whether the %s failed", kind),
+		},
+		"started": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: fmt.Sprintf("Start time of the %s, RFC 3339", kind),
+		},
+		"finished": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: fmt.Sprintf("Finish time of the %s, RFC 3339", kind),
+		},
+		"elapsed": &schema.Schema{
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: fmt.Sprintf("Run time of the %s in seconds", kind),
+		},
+	}
+	for key, value := range shared {
+		if _, ok := s[key]; !ok {
+			s[key] = value
+		}
+	}
+	return s
+}
+
+// setUnifiedJobResourceData sets the computed attributes of unifiedJobSchema.
+func setUnifiedJobResourceData(d *schema.ResourceData, r *unifiedJob) *schema.ResourceData {
+	d.Set("status", r.Status)
+	d.Set("failed", r.Failed)
+	d.Set("started", formatJobTime(r.Started))
+	d.Set("finished", formatJobTime(r.Finished))
+	d.Set("elapsed", r.Elapsed)
+	return d
+}
+
+// waitForCondition polls check until it reports true, for waits that don't
+// follow the status of a single job. The deadline of ctx bounds the wait.
+func waitForCondition(ctx context.Context, description string, check func() (bool, error)) error {
+	for {
+		done, err := check()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		log.Printf("[DEBUG] waiting for %s, checking again in %s", description, unifiedJobPollInterval)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for %s", description)
+		case <-time.After(unifiedJobPollInterval):
+		}
+	}
+}
+
+// formatJobTime returns t as RFC 3339 timestamp, or an empty string when the
+// job didn't start or finish yet.
+func formatJobTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,86 @@
+package awx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	awx "github.com/mrcrilly/goawx/client"
+)
+
+func TestWaitForLaunchedJob(t *testing.T) {
+	cases := []struct {
+		name       string
+		response   string
+		failOnJob  bool
+		failures   string
+		severity   diag.Severity
+		diagnostic string
+	}{
+		{name: "successful", response: `{"id":7,"status":"successful"}`},
+		{
+			name:       "failed",
+			response:   `{"id":7,"status":"failed","failed":true}`,
+			severity:   diag.Warning,
+			diagnostic: "Job 7 of JobTemplate with id 3 finished with status failed",
+		},
+		{
+			name:       "failed with fail_on_job_failure",
+			response:   `{"id":7,"status":"error","failed":true}`,
+			failOnJob:  true,
+			severity:   diag.Error,
+			diagnostic: "Job 7 of JobTemplate with id 3 finished with status error",
+		},
+		{
+			name:       "successful with failures",
+			response:   `{"id":7,"status":"successful"}`,
+			failures:   ", failed nodes: a",
+			severity:   diag.Warning,
+			diagnostic: "finished with status successful, failed nodes: a",
+		},
+		{
+			name:       "missing job",
+			severity:   diag.Error,
+			diagnostic: "Job 7 of JobTemplate with id 3 didn't finish",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v2/jobs/7/" || c.response == "" {
+					http.NotFound(w, r)
+					return
+				}
+				w.Write([]byte(c.response))
+			}))
+			defer srv.Close()
+			client := &awx.AWX{}
+			registerRequester(client, &awx.Requester{Base: srv.URL, Client: srv.Client()})
+
+			r := &schema.Resource{Schema: unifiedJobSchema("job", map[string]*schema.Schema{})}
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"fail_on_job_failure": c.failOnJob})
+			read := func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+				return nil
+			}
+			job := launchedJob{kind: "Job", endpoint: jobsAPIEndpoint, id: 7, origin: "of JobTemplate with id 3"}
+			if c.failures != "" {
+				job.failures = func(d *schema.ResourceData) string { return c.failures }
+			}
+
+			diags := waitForLaunchedJob(context.Background(), d, client, job, read)
+			if c.diagnostic == "" {
+				if len(diags) != 0 {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if len(diags) != 1 || diags[0].Severity != c.severity || !strings.Contains(diags[0].Detail, c.diagnostic) {
+				t.Fatalf("expected one diagnostic with severity %v containing %q, got %v", c.severity, c.diagnostic, diags)
+			}
+		})
+	}
+}
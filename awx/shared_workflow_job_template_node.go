@@ -2,24 +2,22 @@ package awx
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"reflect"
+	"sort"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	awx "github.com/mrcrilly/goawx/client"
 )
 
-var workflowJobNodeSchema = map[string]*schema.Schema{
+var workflowJobNodeSchema = addWorkflowNodePromptSchema(addWorkflowNodeTargetSchema(map[string]*schema.Schema{
 
-	"extra_data": &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Default:     "",
-		Description: "",
-		StateFunc:   normalizeJsonYaml,
-	},
+	"extra_data": workflowNodeExtraDataSchema(),
 	"workflow_job_template_node_id": &schema.Schema{
 		Type:        schema.TypeInt,
 		Optional:    true,
@@ -65,10 +63,6 @@ var workflowJobNodeSchema = map[string]*schema.Schema{
 	//	Type:     schema.TypeInt,
 	//	Required: true,
 	//},
-	"unified_job_template_id": &schema.Schema{
-		Type:     schema.TypeInt,
-		Required: true,
-	},
 	"all_parents_must_converge": &schema.Schema{
 		Type:     schema.TypeBool,
 		Optional: true,
@@ -78,40 +72,616 @@ var workflowJobNodeSchema = map[string]*schema.Schema{
 		Type:     schema.TypeString,
 		Required: true,
 	},
-}
+}))
 
 func createNodeForWorkflowJob(awxService *awx.WorkflowJobTemplateNodeStepService, ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
 	templateNodeID := d.Get("workflow_job_template_node_id").(int)
-	result, err := awxService.CreateWorkflowJobTemplateNodeStep(templateNodeID, map[string]interface{}{
-		//"extra_data": d.Get("extra_data").(string),
-		"inventory":  d.Get("inventory_id").(int),
-		"scm_branch": d.Get("scm_branch").(string),
-		"skip_tags":  d.Get("skip_tags").(string),
-		"job_type":   d.Get("job_type").(string),
-		"job_tags":   d.Get("job_tags").(string),
-		//"limit":      d.Get("limit").(string),
-		//"diff_mode":  d.Get("diff_mode").(bool),
-		"verbosity": d.Get("verbosity").(int),
-		//"workflow_job_template": d.Get("workflow_job_template_id").(int),
-		"unified_job_template": d.Get("unified_job_template_id").(int),
-		//"failure_nodes":         d.Get("failure_nodes").([]interface{}),
-		//"success_nodes":         d.Get("success_nodes").([]interface{}),
-		//"always_nodes":          d.Get("always_nodes").([]interface{}),
-
-		"all_parents_must_converge": d.Get("all_parents_must_converge").(bool),
-		"identifier":                d.Get("identifier").(string),
-	}, map[string]string{})
+	data, err := expandWorkflowNodePrompts(d)
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"Create: Invalid extra_data",
+			"WorkflowJobTemplateNode %s has invalid extra_data: %s", d.Get("identifier").(string), err.Error(),
+		)
+	}
+	//data["failure_nodes"] = d.Get("failure_nodes").([]interface{})
+	//data["success_nodes"] = d.Get("success_nodes").([]interface{})
+	//data["always_nodes"] = d.Get("always_nodes").([]interface{})
+	data["all_parents_must_converge"] = d.Get("all_parents_must_converge").(bool)
+	data["identifier"] = d.Get("identifier").(string)
+
+	expandWorkflowNodeTarget(d, data, true)
+	result, err := awxService.CreateWorkflowJobTemplateNodeStep(templateNodeID, data, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Template %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create WorkflowJobTemplateNodeSuccess",
-			Detail:   fmt.Sprintf("WorkflowJobTemplateNodeSuccess with JobTemplateID %d faild to create %s", d.Get("unified_job_template_id").(int), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail(
+			"Unable to create WorkflowJobTemplateNodeSuccess", err, workflowNodeFieldAttributes,
+			"WorkflowJobTemplateNodeSuccess with JobTemplateID %v faild to create %s", data["unified_job_template"], err.Error(),
+		)
 	}
-	log.Printf("dasdasdasdas %v", result)
 	d.SetId(strconv.Itoa(result.ID))
+	if diags := updateWorkflowNodeApproval(d, m, result.ID, nil); diags.HasError() {
+		return diags
+	}
+	if diags := updateWorkflowNodeAssociations(d, m, result.ID, true); diags.HasError() {
+		return diags
+	}
 	return resourceWorkflowJobTemplateNodeRead(ctx, d, m)
 }
+
+const (
+	workflowJobTemplateNodesAPIEndpoint  = "/api/v2/workflow_job_template_nodes/"
+	workflowApprovalTemplatesAPIEndpoint = "/api/v2/workflow_approval_templates/"
+)
+
+// workflowNodeTargets are the arguments selecting what a workflow node runs,
+// exactly one of them is set.
+var workflowNodeTargets = []string{
+	"unified_job_template_id", "project_id", "inventory_source_id", "nested_workflow_job_template_id", "approval",
+}
+
+// workflowNodeTargetKeys maps the unified_job_type AWX reports for the
+// template of a node to the target argument and the node_type.
+var workflowNodeTargetKeys = map[string][2]string{
+	"job":               {"", "job_template"},
+	"system_job":        {"", "system_job_template"},
+	"project_update":    {"project_id", "project"},
+	"inventory_update":  {"inventory_source_id", "inventory_source"},
+	"workflow_job":      {"nested_workflow_job_template_id", "workflow_job_template"},
+	"workflow_approval": {"", "approval"},
+}
+
+// workflowNodePrompts are the launch prompts of a node, only job templates and
+// workflow job templates accept them.
+var workflowNodePrompts = []string{
+	"extra_data", "inventory", "scm_branch", "job_type", "job_tags", "skip_tags", "limit", "diff_mode", "verbosity",
+	"execution_environment", "forks", "timeout", "job_slice_count",
+}
+
+// workflowNodeJobOnlyPrompts are the prompts workflow job templates don't
+// accept, nested workflows drop them.
+var workflowNodeJobOnlyPrompts = []string{
+	"job_type", "diff_mode", "verbosity", "execution_environment", "forks", "timeout", "job_slice_count",
+}
+
+// workflowNodeAssociations maps the arguments of the prompted many to many
+// relations of a node to their sub-endpoints.
+var workflowNodeAssociations = map[string]string{
+	"credential_ids":     "credentials",
+	"label_ids":          "labels",
+	"instance_group_ids": "instance_groups",
+}
+
+var workflowNodeFieldAttributes = map[string]string{
+	"unified_job_template":  "unified_job_template_id",
+	"inventory":             "inventory_id",
+	"execution_environment": "execution_environment_id",
+	"credentials":           "credential_ids",
+	"labels":                "label_ids",
+	"instance_groups":       "instance_group_ids",
+}
+
+var workflowApprovalFieldAttributes = map[string]string{
+	"name":    "approval",
+	"timeout": "approval",
+}
+
+// workflowJobTemplateNode is a node as AWX returns it. The client decodes
+// extra_data and diff_mode with the wrong types and lacks the newer prompts.
+type workflowJobTemplateNode struct {
+	awx.WorkflowJobTemplateNode
+	ExtraData            map[string]interface{} `json:"extra_data"`
+	DiffMode             *bool                  `json:"diff_mode"`
+	ExecutionEnvironment int                    `json:"execution_environment"`
+	Forks                *int                   `json:"forks"`
+	Timeout              *int                   `json:"timeout"`
+	JobSliceCount        *int                   `json:"job_slice_count"`
+}
+
+type workflowApprovalTemplate struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Timeout     int    `json:"timeout"`
+}
+
+// workflowNodeExtraDataSchema is the extra_data prompt of the node resources
+// and of the nodes of awx_workflow_job_template_graph. The state holds it as
+// canonical JSON, like AWX returns it.
+func workflowNodeExtraDataSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "",
+		StateFunc:    normalizeExtraVars,
+		ValidateFunc: validateExtraVars,
+		Description:  "Extra variables of the node in JSON or YAML",
+	}
+}
+
+// workflowNodeStateUpgraders upgrades the states of the node resources with
+// schema s from version 0, see upgradeWorkflowNodeStateV0.
+func workflowNodeStateUpgraders(s map[string]*schema.Schema) []schema.StateUpgrader {
+	return []schema.StateUpgrader{{
+		Version: 0,
+		Type:    (&schema.Resource{Schema: s}).CoreConfigSchema().ImpliedType(),
+		Upgrade: upgradeWorkflowNodeStateV0,
+	}}
+}
+
+// upgradeWorkflowNodeStateV0 rewrites extra_data as canonical JSON. Version 0
+// stored YAML extra_data as normalized YAML, the first plan after upgrading
+// would show a change for every node configured with YAML.
+func upgradeWorkflowNodeStateV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if extraData, ok := rawState["extra_data"].(string); ok {
+		rawState["extra_data"] = normalizeExtraVars(extraData)
+	}
+	return rawState, nil
+}
+
+// addWorkflowNodeTargetSchema adds the target arguments of a workflow node to
+// the schema s of a node resource.
+func addWorkflowNodeTargetSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["unified_job_template_id"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: workflowNodeTargets,
+		Description:  "Numeric ID of the job template or other unified job template the node runs",
+	}
+	s["project_id"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: workflowNodeTargets,
+		Description:  "Numeric ID of the project the node updates",
+	}
+	s["inventory_source_id"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: workflowNodeTargets,
+		Description:  "Numeric ID of the inventory source the node syncs",
+	}
+	s["nested_workflow_job_template_id"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: workflowNodeTargets,
+		Description:  "Numeric ID of the workflow job template the node runs as a nested workflow",
+	}
+	s["approval"] = &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		ExactlyOneOf: workflowNodeTargets,
+		Description:  "Makes the node an approval node",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": &schema.Schema{
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Name of the approval",
+				},
+				"description": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "",
+					Description: "Description of the approval",
+				},
+				"timeout": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "Seconds until the approval is denied, 0 waits forever",
+				},
+			},
+		},
+	}
+	s["node_type"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "One of job_template, project, inventory_source, workflow_job_template, approval, system_job_template",
+	}
+	return s
+}
+
+// workflowNodeTarget returns the configured target argument of a node.
+func workflowNodeTarget(d *schema.ResourceData) string {
+	switch {
+	case len(d.Get("approval").([]interface{})) > 0:
+		// Absent blocks are empty lists in the raw config, not null.
+		return "approval"
+	case isConfigured(d, "project_id"):
+		return "project_id"
+	case isConfigured(d, "inventory_source_id"):
+		return "inventory_source_id"
+	case isConfigured(d, "nested_workflow_job_template_id"):
+		return "nested_workflow_job_template_id"
+	default:
+		return "unified_job_template_id"
+	}
+}
+
+// resourceWorkflowNodeCustomizeDiff rejects prompted credentials, labels and
+// instance groups on nodes whose target doesn't accept them, they can't be
+// associated and the plan would never converge.
+func resourceWorkflowNodeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := d.GetRawConfig()
+	configured := func(key string) bool {
+		if config.IsNull() || !config.IsKnown() {
+			_, ok := d.GetOk(key)
+			return ok
+		}
+		return !config.GetAttr(key).IsNull()
+	}
+	nodeType := "job_template"
+	if len(d.Get("approval").([]interface{})) > 0 {
+		nodeType = "approval"
+	} else {
+		for _, keys := range workflowNodeTargetKeys {
+			if keys[0] != "" && configured(keys[0]) {
+				nodeType = keys[1]
+			}
+		}
+	}
+
+	for _, key := range []string{"credential_ids", "label_ids", "instance_group_ids"} {
+		if !d.NewValueKnown(key) || len(expandWorkflowNodeIDs(d.Get(key))) == 0 {
+			continue
+		}
+		if !workflowNodeAcceptsRelation(nodeType, workflowNodeAssociations[key]) {
+			return fmt.Errorf("%s can't be set on %s nodes", key, nodeType)
+		}
+	}
+	return nil
+}
+
+// expandWorkflowNodeTarget sets the unified job template of the configured
+// target in data and drops the prompts the target doesn't accept. Approval
+// nodes get their template from updateWorkflowNodeApproval once the node
+// exists, their template is kept on update.
+func expandWorkflowNodeTarget(d *schema.ResourceData, data map[string]interface{}, create bool) {
+	dropPrompts := func() {
+		for _, key := range workflowNodePrompts {
+			delete(data, key)
+		}
+	}
+	switch target := workflowNodeTarget(d); target {
+	case "approval":
+		dropPrompts()
+		delete(data, "unified_job_template")
+		if create {
+			data["unified_job_template"] = nil
+		}
+	case "project_id", "inventory_source_id":
+		dropPrompts()
+		data["unified_job_template"] = d.Get(target).(int)
+	case "nested_workflow_job_template_id":
+		// Workflow job templates don't prompt for these.
+		for _, key := range workflowNodeJobOnlyPrompts {
+			delete(data, key)
+		}
+		data["unified_job_template"] = d.Get(target).(int)
+	default:
+		data["unified_job_template"] = d.Get(target).(int)
+	}
+}
+
+// updateWorkflowNodeApproval creates the approval template of an approval node,
+// or updates it when the node already runs an approval template.
+func updateWorkflowNodeApproval(d *schema.ResourceData, m interface{}, id int, current *awx.WorkflowJobTemplateNode) diag.Diagnostics {
+	approvals := d.Get("approval").([]interface{})
+	if len(approvals) == 0 || approvals[0] == nil {
+		return nil
+	}
+	approval := approvals[0].(map[string]interface{})
+	data := map[string]interface{}{
+		"name":        approval["name"].(string),
+		"description": approval["description"].(string),
+		"timeout":     approval["timeout"].(int),
+	}
+
+	var err error
+	if current != nil && workflowNodeUnifiedJobType(current) == "workflow_approval" {
+		err = apiPatch(m, fmt.Sprintf("%s%d/", workflowApprovalTemplatesAPIEndpoint, current.UnifiedJobTemplate), data, nil)
+	} else {
+		err = apiPost(m, fmt.Sprintf("%s%d/create_approval_template/", workflowJobTemplateNodesAPIEndpoint, id), data, nil)
+	}
+	if err != nil {
+		log.Printf("Fail to save WorkflowApprovalTemplate %v", err)
+		return buildDiagAPIFail(
+			"Unable to save WorkflowApprovalTemplate", err, workflowApprovalFieldAttributes,
+			"WorkflowApprovalTemplate of WorkflowJobTemplateNode with id %d faild to save %s", id, err.Error(),
+		)
+	}
+	return nil
+}
+
+// setWorkflowNodeTargetResourceData sets the target argument matching the
+// type of the template the node runs and clears the others.
+func setWorkflowNodeTargetResourceData(d *schema.ResourceData, m interface{}, r *awx.WorkflowJobTemplateNode) error {
+	unifiedJobType := workflowNodeUnifiedJobType(r)
+	keys := workflowNodeTargetKeys[unifiedJobType]
+	for _, key := range []string{"project_id", "inventory_source_id", "nested_workflow_job_template_id"} {
+		if key == keys[0] {
+			d.Set(key, r.UnifiedJobTemplate)
+		} else {
+			d.Set(key, 0)
+		}
+	}
+	d.Set("unified_job_template_id", r.UnifiedJobTemplate)
+	d.Set("node_type", keys[1])
+
+	if unifiedJobType != "workflow_approval" {
+		d.Set("approval", nil)
+		return nil
+	}
+	approval := new(workflowApprovalTemplate)
+	if err := apiGet(m, fmt.Sprintf("%s%d/", workflowApprovalTemplatesAPIEndpoint, r.UnifiedJobTemplate), approval, nil); err != nil {
+		return err
+	}
+	d.Set("approval", []interface{}{map[string]interface{}{
+		"name":        approval.Name,
+		"description": approval.Description,
+		"timeout":     approval.Timeout,
+	}})
+	return nil
+}
+
+func workflowNodeUnifiedJobType(r *awx.WorkflowJobTemplateNode) string {
+	if r.SummaryFields == nil || r.SummaryFields.UnifiedJobTemplate == nil {
+		return ""
+	}
+	return r.SummaryFields.UnifiedJobTemplate.UnifiedJobType
+}
+
+// addWorkflowNodePromptSchema adds the prompts AWX accepts on a node besides
+// the classic ones to the schema s of a node resource.
+func addWorkflowNodePromptSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["execution_environment_id"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Description: "Execution environment applied as a prompt, assuming the job template prompts for it",
+	}
+	s["forks"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  "Forks applied as a prompt, assuming the job template prompts for forks",
+	}
+	s["timeout"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  "Timeout in seconds applied as a prompt, assuming the job template prompts for the timeout",
+	}
+	s["job_slice_count"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+		Description:  "Job slices applied as a prompt, assuming the job template prompts for the slice count",
+	}
+	s["credential_ids"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeInt},
+		Description: "Credentials applied as a prompt, assuming the job template prompts for credentials",
+	}
+	s["label_ids"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeInt},
+		Description: "Labels applied as a prompt, assuming the template prompts for labels",
+	}
+	s["instance_group_ids"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeInt},
+		Description: "Instance groups in order of preference applied as a prompt, assuming the job template prompts for them",
+	}
+	return s
+}
+
+// expandWorkflowNodePrompts returns the payload of the prompts of a node,
+// prompts that aren't set are cleared.
+func expandWorkflowNodePrompts(d *schema.ResourceData) (map[string]interface{}, error) {
+	extraData, err := expandExtraVars(d.Get("extra_data").(string))
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]interface{}{
+		"extra_data":            extraData,
+		"inventory":             nil,
+		"scm_branch":            d.Get("scm_branch").(string),
+		"job_type":              d.Get("job_type").(string),
+		"job_tags":              d.Get("job_tags").(string),
+		"skip_tags":             d.Get("skip_tags").(string),
+		"limit":                 d.Get("limit").(string),
+		"diff_mode":             nil,
+		"verbosity":             d.Get("verbosity").(int),
+		"execution_environment": nil,
+		"forks":                 nil,
+		"timeout":               nil,
+		"job_slice_count":       nil,
+	}
+	if v := d.Get("inventory_id").(int); v != 0 {
+		data["inventory"] = v
+	}
+	if v := d.Get("execution_environment_id").(int); v != 0 {
+		data["execution_environment"] = v
+	}
+	if isConfigured(d, "diff_mode") {
+		data["diff_mode"] = d.Get("diff_mode").(bool)
+	}
+	for _, key := range []string{"forks", "timeout", "job_slice_count"} {
+		if isConfigured(d, key) {
+			data[key] = d.Get(key).(int)
+		}
+	}
+	return data, nil
+}
+
+// workflowNodeAcceptsRelation reports whether nodes of nodeType accept the
+// prompted relation, workflow job templates only prompt for labels.
+func workflowNodeAcceptsRelation(nodeType, relation string) bool {
+	switch nodeType {
+	case "job_template":
+		return true
+	case "workflow_job_template":
+		return relation == "labels"
+	}
+	return false
+}
+
+// updateWorkflowNodeAssociations associates and disassociates the prompted
+// credentials, labels and instance groups of node id until they match the
+// configuration. Instance groups are ordered, they are associated again when
+// the order changed.
+func updateWorkflowNodeAssociations(d *schema.ResourceData, m interface{}, id int, create bool) diag.Diagnostics {
+	nodeType := map[string]string{
+		"unified_job_template_id":         "job_template",
+		"nested_workflow_job_template_id": "workflow_job_template",
+	}[workflowNodeTarget(d)]
+
+	for _, key := range []string{"credential_ids", "label_ids", "instance_group_ids"} {
+		relation := workflowNodeAssociations[key]
+		var desired []int
+		if workflowNodeAcceptsRelation(nodeType, relation) {
+			desired = expandWorkflowNodeIDs(d.Get(key))
+		}
+		if create && len(desired) == 0 {
+			continue
+		}
+		if !create && !d.HasChange(key) && !d.HasChanges(workflowNodeTargets...) {
+			continue
+		}
+
+		if diags := syncWorkflowNodeAssociation(m, id, key, desired); diags.HasError() {
+			return diags
+		}
+	}
+	return nil
+}
+
+// syncWorkflowNodeAssociation associates and disassociates the relation of
+// node id given as the argument key until it holds the ids desired.
+func syncWorkflowNodeAssociation(m interface{}, id int, key string, desired []int) diag.Diagnostics {
+	relation := workflowNodeAssociations[key]
+	endpoint := fmt.Sprintf("%s%d/%s/", workflowJobTemplateNodesAPIEndpoint, id, relation)
+	current, err := listWorkflowNodeAssociation(m, id, relation)
+	if err != nil {
+		return buildDiagNotFoundFail("workflow job template node "+relation, id, err)
+	}
+
+	var remove, add []int
+	if relation == "instance_groups" {
+		if !reflect.DeepEqual(current, desired) && !(len(current) == 0 && len(desired) == 0) {
+			remove, add = current, desired
+		}
+	} else {
+		for _, v := range current {
+			if !containsInt(desired, v) {
+				remove = append(remove, v)
+			}
+		}
+		for _, v := range desired {
+			if !containsInt(current, v) {
+				add = append(add, v)
+			}
+		}
+	}
+
+	for _, v := range remove {
+		if err := apiPost(m, endpoint, map[string]interface{}{"id": v, "disassociate": true}, nil); err != nil {
+			return buildDiagAPIFail(
+				"Unable to update WorkflowJobTemplateNode", err, map[string]string{"id": key},
+				"WorkflowJobTemplateNode with id %d faild to disassociate %s %d %s", id, relation, v, err.Error(),
+			)
+		}
+	}
+	for _, v := range add {
+		if err := apiPost(m, endpoint, map[string]interface{}{"id": v}, nil); err != nil {
+			return buildDiagAPIFail(
+				"Unable to update WorkflowJobTemplateNode", err, map[string]string{"id": key},
+				"WorkflowJobTemplateNode with id %d faild to associate %s %d %s", id, relation, v, err.Error(),
+			)
+		}
+	}
+	return nil
+}
+
+func expandWorkflowNodeIDs(v interface{}) []int {
+	var items []interface{}
+	switch value := v.(type) {
+	case *schema.Set:
+		items = value.List()
+		sort.Slice(items, func(i, j int) bool { return items[i].(int) < items[j].(int) })
+	case []interface{}:
+		items = value
+	}
+	ids := make([]int, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.(int))
+	}
+	return ids
+}
+
+func listWorkflowNodeAssociation(m interface{}, id int, relation string) ([]int, error) {
+	ids := []int{}
+	endpoint := fmt.Sprintf("%s%d/%s/", workflowJobTemplateNodesAPIEndpoint, id, relation)
+	err := apiGetAll(m, endpoint, nil, func(results json.RawMessage) error {
+		var page []struct {
+			ID int `json:"id"`
+		}
+		if err := json.Unmarshal(results, &page); err != nil {
+			return err
+		}
+		for _, item := range page {
+			ids = append(ids, item.ID)
+		}
+		return nil
+	})
+	return ids, err
+}
+
+func getWorkflowJobTemplateNode(m interface{}, id int) (*workflowJobTemplateNode, error) {
+	result := new(workflowJobTemplateNode)
+	if err := apiGet(m, fmt.Sprintf("%s%d/", workflowJobTemplateNodesAPIEndpoint, id), result, nil); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// setWorkflowNodePromptResourceData sets the prompts the client can't decode
+// and the prompted credentials, labels and instance groups of node r.
+func setWorkflowNodePromptResourceData(d *schema.ResourceData, m interface{}, r *workflowJobTemplateNode) error {
+	extraData := ""
+	if len(r.ExtraData) > 0 {
+		b, _ := json.Marshal(r.ExtraData)
+		extraData = string(b)
+	}
+	d.Set("extra_data", extraData)
+	d.Set("diff_mode", r.DiffMode != nil && *r.DiffMode)
+	d.Set("execution_environment_id", r.ExecutionEnvironment)
+	for key, value := range map[string]*int{"forks": r.Forks, "timeout": r.Timeout, "job_slice_count": r.JobSliceCount} {
+		if value != nil {
+			d.Set(key, *value)
+		} else {
+			d.Set(key, nil)
+		}
+	}
+
+	nodeType := workflowNodeTargetKeys[workflowNodeUnifiedJobType(&r.WorkflowJobTemplateNode)][1]
+	for key, relation := range workflowNodeAssociations {
+		ids := []int{}
+		if workflowNodeAcceptsRelation(nodeType, relation) {
+			var err error
+			if ids, err = listWorkflowNodeAssociation(m, r.ID, relation); err != nil {
+				return err
+			}
+		}
+		d.Set(key, ids)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package awx
+
+import (
+	"context"
+	"testing"
+)
+
+func TestUpgradeWorkflowNodeStateV0(t *testing.T) {
+	cases := []struct {
+		name      string
+		extraData interface{}
+		expected  interface{}
+	}{
+		{name: "YAML", extraData: "b: x\na: 1\n", expected: `{"a":1,"b":"x"}`},
+		{name: "JSON", extraData: `{"b": "x", "a": 1}`, expected: `{"a":1,"b":"x"}`},
+		{name: "empty", extraData: "", expected: ""},
+		{name: "empty object", extraData: "{}", expected: ""},
+		{name: "invalid", extraData: "[1", expected: "[1"},
+		{name: "absent", extraData: nil, expected: nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			state := map[string]interface{}{"identifier": "a"}
+			if c.extraData != nil {
+				state["extra_data"] = c.extraData
+			}
+			upgraded, err := upgradeWorkflowNodeStateV0(context.Background(), state, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if upgraded["extra_data"] != c.expected || upgraded["identifier"] != "a" {
+				t.Fatalf("expected extra_data %v, got %v", c.expected, upgraded)
+			}
+		})
+	}
+}
+
+func TestValidateExtraVars(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"":             true,
+		`{"a": 1}`:     true,
+		"a: 1\nb: [x]": true,
+		"[1, 2]":       false,
+		"plain text":   false,
+	} {
+		_, errs := validateExtraVars(value, "extra_data")
+		if (len(errs) == 0) != valid {
+			t.Errorf("%q: expected valid %t, got %v", value, valid, errs)
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 )
 
 func main() {
+	defer awx.Shutdown()
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: func() *schema.Provider {
 			return awx.Provider()